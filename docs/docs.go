@@ -272,16 +272,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/advertisers/{advertiserId}/campaigns": {
+        "/advertisers/{advertiserId}/audiences": {
             "get": {
-                "description": "Возвращает кампании рекламодателя по его ID",
+                "description": "Возвращает аудитории рекламодателя",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Campaigns"
+                    "Audiences"
                 ],
-                "summary": "Получение кампаний рекламодателя",
+                "summary": "Получение аудиторий рекламодателя",
                 "parameters": [
                     {
                         "type": "string",
@@ -289,6 +289,18 @@ const docTemplate = `{
                         "name": "advertiserId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -297,7 +309,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Campaign"
+                                "$ref": "#/definitions/domain.Audience"
                             }
                         }
                     },
@@ -322,7 +334,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создает рекламную кампанию",
+                "description": "Создает именованную аудиторию из правил таргетинга и (необязательно) списка ID клиентов. Аудиторию можно подключить к нескольким кампаниям рекламодателя",
                 "consumes": [
                     "application/json"
                 ],
@@ -330,32 +342,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Campaigns"
+                    "Audiences"
                 ],
-                "summary": "Создание кампании",
+                "summary": "Создание аудитории",
                 "parameters": [
-                    {
-                        "description": "CampaignRequest",
-                        "name": "CreateCampaign",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CampaignRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Advertiser ID",
+                        "description": "ID рекламодателя",
                         "name": "advertiserId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Аудитория",
+                        "name": "CreateAudience",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AudienceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Campaign"
+                            "$ref": "#/definitions/domain.Audience"
                         }
                     },
                     "400": {
@@ -379,16 +391,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/advertisers/{advertiserId}/campaigns/{campaignId}": {
+        "/advertisers/{advertiserId}/audiences/{audienceId}": {
             "get": {
-                "description": "Возвращает кампанию по ее ID",
+                "description": "Возвращает аудиторию рекламодателя по ее ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Campaigns"
+                    "Audiences"
                 ],
-                "summary": "Получение кампании",
+                "summary": "Получение аудитории",
                 "parameters": [
                     {
                         "type": "string",
@@ -399,8 +411,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID рекламной кампании",
-                        "name": "campaignId",
+                        "description": "ID аудитории",
+                        "name": "audienceId",
                         "in": "path",
                         "required": true
                     }
@@ -409,7 +421,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Campaign"
+                            "$ref": "#/definitions/domain.Audience"
                         }
                     },
                     "400": {
@@ -433,7 +445,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Обновляет разрешённые параметры рекламной кампании",
+                "description": "Заменяет название, таргетинг и список клиентов аудитории. Изменения применяются ко всем кампаниям с этой аудиторией",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,14 +453,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Campaigns"
+                    "Audiences"
+                ],
+                "summary": "Обновление аудитории",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID аудитории",
+                        "name": "audienceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Аудитория",
+                        "name": "UpdateAudience",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AudienceRequest"
+                        }
+                    }
                 ],
-                "summary": "Обновление кампании",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Campaign"
+                            "$ref": "#/definitions/domain.Audience"
                         }
                     },
                     "400": {
@@ -472,21 +509,49 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет рекламную кампанию по ее ID",
+                "description": "Удаляет аудиторию и отключает ее от архивных и удаленных кампаний. Аудиторию, которую используют другие кампании, удалить нельзя",
                 "tags": [
-                    "Campaigns"
+                    "Audiences"
+                ],
+                "summary": "Удаление аудитории",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID аудитории",
+                        "name": "audienceId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Удаление рекламной кампании",
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -496,45 +561,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/advertisers/{advertiserId}/campaigns/{campaignId}/picture": {
+        "/advertisers/{advertiserId}/balance/topup": {
             "post": {
-                "description": "Добавляет/обновляет изображение рекламной кампании",
+                "description": "Пополняет баланс рекламодателя и записывает пополнение в журнал операций",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Campaigns"
+                    "Advertisers"
                 ],
-                "summary": "Добавление картинки к рекламной кампании",
+                "summary": "Пополнение баланса рекламодателя",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID рекламодателя",
+                        "description": "ID рекламодателя",
                         "name": "advertiserId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "UUID рекламной кампании",
-                        "name": "campaignId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Файл изображения для загрузки",
-                        "name": "uploadfile",
-                        "in": "formData",
-                        "required": true
+                        "description": "TopUpRequest",
+                        "name": "TopUpRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TopUpRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Advertiser"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -557,40 +620,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/bulk": {
-            "post": {
-                "description": "Создает новых или обновляет существующих клиентов",
-                "consumes": [
-                    "application/json"
-                ],
+        "/advertisers/{advertiserId}/campaigns": {
+            "get": {
+                "description": "Возвращает кампании рекламодателя по его ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Campaigns"
                 ],
-                "summary": "Массовое создание/обновление клиентов",
+                "summary": "Получение кампаний рекламодателя",
                 "parameters": [
                     {
-                        "description": "Clients",
-                        "name": "clients",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.User"
-                            }
-                        }
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.User"
+                                "$ref": "#/definitions/domain.Campaign"
                             }
                         }
                     },
@@ -600,6 +655,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -607,35 +668,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/clients/{clientId}": {
-            "get": {
-                "description": "Возвращает информацию о клиенте по его ID",
+            },
+            "post": {
+                "description": "Создает рекламную кампанию",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Campaigns"
                 ],
-                "summary": "Получение клиента по ID",
+                "summary": "Создание кампании",
                 "parameters": [
+                    {
+                        "description": "CampaignRequest",
+                        "name": "CreateCampaign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CampaignRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "UUID клиента",
-                        "name": "clientId",
+                        "description": "Advertiser ID",
+                        "name": "advertiserId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.User"
-                            }
+                            "$ref": "#/definitions/domain.Campaign"
                         }
                     },
                     "400": {
@@ -659,9 +727,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ml-scores": {
+        "/advertisers/{advertiserId}/campaigns/estimate": {
             "post": {
-                "description": "Добавляет или обновляет ML скор для указанной пары клиент-рекламодатель",
+                "description": "Возвращает количество клиентов, подходящих таргетингу (и аудитории, если указана), и распределение ML скоров рекламодателя среди них. Клиенты проверяются по тем же правилам, что и при выборе рекламы",
                 "consumes": [
                     "application/json"
                 ],
@@ -669,17 +737,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Advertisers"
+                    "Campaigns"
                 ],
-                "summary": "Добавление или обновление ML скора",
+                "summary": "Оценка охвата таргетинга",
                 "parameters": [
                     {
-                        "description": "MLScore",
-                        "name": "MLScore",
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Таргетинг",
+                        "name": "Estimate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.MLScore"
+                            "$ref": "#/definitions/domain.EstimateRequest"
                         }
                     }
                 ],
@@ -687,7 +762,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.MLScore"
+                            "$ref": "#/definitions/domain.AudienceEstimate"
                         }
                     },
                     "400": {
@@ -711,35 +786,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/time/advance": {
-            "post": {
-                "description": "Устанавливает текущий день в системе в заданную дату",
-                "consumes": [
-                    "application/json"
-                ],
+        "/advertisers/{advertiserId}/campaigns/{campaignId}": {
+            "get": {
+                "description": "Возвращает кампанию по ее ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Time"
+                    "Campaigns"
                 ],
-                "summary": "Установка текущей даты",
+                "summary": "Получение кампании",
                 "parameters": [
                     {
-                        "description": "Новый текущий день",
-                        "name": "newDate",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CurrentDate"
-                        }
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.CurrentDate"
+                            "$ref": "#/definitions/domain.Campaign"
                         }
                     },
                     "400": {
@@ -748,6 +825,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -755,18 +838,1166 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "domain.Advertiser": {
-            "type": "object",
+            },
+            "put": {
+                "description": "Обновляет разрешённые параметры рекламной кампании",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Обновление кампании",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет рекламную кампанию по ее ID. Удаленная кампания не показывается и не возвращается в списке, но ее статистика и операции сохраняются. С hard=true кампания удаляется полностью вместе с показами и кликами, это административная операция, доступная только при ADMIN_ALLOW_CAMPAIGN_PURGE=true",
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Удаление рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Полное удаление",
+                        "name": "hard",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Применяет к рекламной кампании JSON Merge Patch (RFC 7396): переданные поля изменяются, отсутствующие остаются прежними, null сбрасывает необязательное поле",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Частичное обновление кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля кампании",
+                        "name": "PatchCampaign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CampaignUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/archive": {
+            "post": {
+                "description": "Переводит рекламную кампанию в статус archived, после этого её нельзя изменить или возобновить",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Архивация рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/clone": {
+            "post": {
+                "description": "Создает черновик с креативом, таргетингом, лимитами и картинкой рекламной кампании и новыми датами. Копия проходит те же проверки и модерацию, что и новая кампания",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Копирование рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID копируемой рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Даты новой кампании",
+                        "name": "CloneCampaign",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CloneCampaignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/pause": {
+            "post": {
+                "description": "Переводит активную рекламную кампанию в статус paused, она перестает показываться",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Приостановка рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/picture": {
+            "post": {
+                "description": "Добавляет/обновляет изображение рекламной кампании",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Добавление картинки к рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения для загрузки",
+                        "name": "uploadfile",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/resume": {
+            "post": {
+                "description": "Переводит приостановленную кампанию или черновик в статус active",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Возобновление рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/versions": {
+            "get": {
+                "description": "Возвращает версии рекламной кампании, начиная с последней. Версия сохраняется при каждом создании и обновлении кампании",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "История изменений рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CampaignVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/campaigns/{campaignId}/versions/{version}/restore": {
+            "post": {
+                "description": "Возвращает заголовок, текст и таргетинг рекламной кампании из указанной версии. Восстановленный текст проходит модерацию, а результат сохраняется как новая версия",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaigns"
+                ],
+                "summary": "Откат рекламной кампании к версии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Campaign"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/invoices": {
+            "get": {
+                "description": "Возвращает счет с затратами на показы и клики по каждой кампании рекламодателя за дни from..to включительно",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Advertisers"
+                ],
+                "summary": "Получение счета рекламодателя за период",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Первый день периода (по умолчанию 0)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Последний день периода (по умолчанию текущий день)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат счета: json или csv (по умолчанию json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/advertisers/{advertiserId}/ledger": {
+            "get": {
+                "description": "Возвращает пополнения баланса и списания за показы и клики, начиная с последних",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Advertisers"
+                ],
+                "summary": "Журнал операций рекламодателя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LedgerEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/clients/bulk": {
+            "post": {
+                "description": "Создает новых или обновляет существующих клиентов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "Массовое создание/обновление клиентов",
+                "parameters": [
+                    {
+                        "description": "Clients",
+                        "name": "clients",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.User"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/clients/{clientId}": {
+            "get": {
+                "description": "Возвращает информацию о клиенте по его ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clients"
+                ],
+                "summary": "Получение клиента по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID клиента",
+                        "name": "clientId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ml-scores": {
+            "post": {
+                "description": "Добавляет или обновляет ML скор для указанной пары клиент-рекламодатель",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Advertisers"
+                ],
+                "summary": "Добавление или обновление ML скора",
+                "parameters": [
+                    {
+                        "description": "MLScore",
+                        "name": "MLScore",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MLScore"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MLScore"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/advertisers/{advertiserId}/campaigns/daily": {
+            "get": {
+                "description": "Возвращает суммарную статистику всех рекламных кампаний рекламодателя за каждый день",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Получение ежедневной статистики по кампаниям рекламодателя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID рекламодателя",
+                        "name": "advertiserId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DailyStats"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/campaigns/{campaignId}": {
+            "get": {
+                "description": "Возвращает количество показов и кликов, конверсию, затраты рекламной кампании и превышение лимита показов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Получение статистики по рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CampaignStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/campaigns/{campaignId}/daily": {
+            "get": {
+                "description": "Возвращает статистику рекламной кампании за каждый день с её старта до текущего дня",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Получение ежедневной статистики по рекламной кампании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID рекламной кампании",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.DailyStats"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/time/advance": {
+            "post": {
+                "description": "Устанавливает текущий день в системе в заданную дату",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time"
+                ],
+                "summary": "Установка текущей даты",
+                "parameters": [
+                    {
+                        "description": "Новый текущий день",
+                        "name": "newDate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CurrentDate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CurrentDate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Advertiser": {
+            "type": "object",
+            "properties": {
+                "advertiser_id": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Audience": {
+            "type": "object",
             "properties": {
                 "advertiser_id": {
                     "type": "string"
                 },
+                "audience_id": {
+                    "type": "string"
+                },
+                "client_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targeting": {
+                    "$ref": "#/definitions/domain.Targeting"
+                }
+            }
+        },
+        "domain.AudienceEstimate": {
+            "type": "object",
+            "properties": {
+                "clients_count": {
+                    "type": "integer"
+                },
+                "scored_clients_count": {
+                    "type": "integer"
+                },
+                "scores": {
+                    "$ref": "#/definitions/domain.ScoreDistribution"
+                }
+            }
+        },
+        "domain.AudienceRequest": {
+            "type": "object",
+            "properties": {
+                "client_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
+                },
+                "targeting": {
+                    "$ref": "#/definitions/domain.Targeting"
                 }
             }
         },
@@ -782,6 +2013,15 @@ const docTemplate = `{
                 "advertiser_id": {
                     "type": "string"
                 },
+                "audience_id": {
+                    "type": "string"
+                },
+                "budget_daily": {
+                    "type": "number"
+                },
+                "budget_total": {
+                    "type": "number"
+                },
                 "campaign_id": {
                     "type": "string"
                 },
@@ -797,15 +2037,33 @@ const docTemplate = `{
                 "end_date": {
                     "type": "integer"
                 },
+                "frequency_cap_daily": {
+                    "type": "integer"
+                },
+                "frequency_cap_total": {
+                    "type": "integer"
+                },
                 "impressions_limit": {
                     "type": "integer"
                 },
+                "pacing": {
+                    "type": "string"
+                },
                 "picture": {
                     "type": "string"
                 },
+                "remaining_budget_daily": {
+                    "type": "number"
+                },
+                "remaining_budget_total": {
+                    "type": "number"
+                },
                 "start_date": {
                     "type": "integer"
                 },
+                "status": {
+                    "type": "string"
+                },
                 "targeting": {
                     "$ref": "#/definitions/domain.Targeting"
                 }
@@ -820,6 +2078,97 @@ const docTemplate = `{
                 "ad_title": {
                     "type": "string"
                 },
+                "audience_id": {
+                    "type": "string"
+                },
+                "budget_daily": {
+                    "type": "number"
+                },
+                "budget_total": {
+                    "type": "number"
+                },
+                "clicks_limit": {
+                    "type": "integer"
+                },
+                "cost_per_click": {
+                    "type": "number"
+                },
+                "cost_per_impression": {
+                    "type": "number"
+                },
+                "end_date": {
+                    "type": "integer"
+                },
+                "frequency_cap_daily": {
+                    "type": "integer"
+                },
+                "frequency_cap_total": {
+                    "type": "integer"
+                },
+                "impressions_limit": {
+                    "type": "integer"
+                },
+                "pacing": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "targeting": {
+                    "$ref": "#/definitions/domain.Targeting"
+                }
+            }
+        },
+        "domain.CampaignStats": {
+            "type": "object",
+            "properties": {
+                "capped_clicks_count": {
+                    "type": "integer"
+                },
+                "clicks_count": {
+                    "type": "integer"
+                },
+                "conversion": {
+                    "type": "number"
+                },
+                "impressions_count": {
+                    "type": "integer"
+                },
+                "impressions_overshoot": {
+                    "type": "integer"
+                },
+                "spent_clicks": {
+                    "type": "number"
+                },
+                "spent_impressions": {
+                    "type": "number"
+                },
+                "spent_total": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.CampaignUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "ad_text": {
+                    "type": "string"
+                },
+                "ad_title": {
+                    "type": "string"
+                },
+                "audience_id": {
+                    "type": "string"
+                },
+                "budget_daily": {
+                    "type": "number"
+                },
+                "budget_total": {
+                    "type": "number"
+                },
                 "clicks_limit": {
                     "type": "integer"
                 },
@@ -832,9 +2181,18 @@ const docTemplate = `{
                 "end_date": {
                     "type": "integer"
                 },
+                "frequency_cap_daily": {
+                    "type": "integer"
+                },
+                "frequency_cap_total": {
+                    "type": "integer"
+                },
                 "impressions_limit": {
                     "type": "integer"
                 },
+                "pacing": {
+                    "type": "string"
+                },
                 "start_date": {
                     "type": "integer"
                 },
@@ -843,6 +2201,20 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CampaignVersion": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/domain.Campaign"
+                },
+                "date": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.Click": {
             "type": "object",
             "properties": {
@@ -851,6 +2223,17 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CloneCampaignRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.CurrentDate": {
             "type": "object",
             "properties": {
@@ -859,6 +2242,46 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.DailyStats": {
+            "type": "object",
+            "properties": {
+                "capped_clicks_count": {
+                    "type": "integer"
+                },
+                "clicks_count": {
+                    "type": "integer"
+                },
+                "conversion": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "integer"
+                },
+                "impressions_count": {
+                    "type": "integer"
+                },
+                "spent_clicks": {
+                    "type": "number"
+                },
+                "spent_impressions": {
+                    "type": "number"
+                },
+                "spent_total": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.EstimateRequest": {
+            "type": "object",
+            "properties": {
+                "audience_id": {
+                    "type": "string"
+                },
+                "targeting": {
+                    "$ref": "#/definitions/domain.Targeting"
+                }
+            }
+        },
         "domain.GenerateAdTextRequest": {
             "type": "object",
             "properties": {
@@ -878,6 +2301,81 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.Invoice": {
+            "type": "object",
+            "properties": {
+                "advertiser_id": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InvoiceItem"
+                    }
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.InvoiceItem": {
+            "type": "object",
+            "properties": {
+                "ad_title": {
+                    "type": "string"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "clicks_amount": {
+                    "type": "number"
+                },
+                "clicks_count": {
+                    "type": "integer"
+                },
+                "impressions_amount": {
+                    "type": "number"
+                },
+                "impressions_count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "advertiser_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.MLScore": {
             "type": "object",
             "properties": {
@@ -892,6 +2390,29 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ScoreDistribution": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "median": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                },
+                "p25": {
+                    "type": "integer"
+                },
+                "p75": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.SwitchModerationResponse": {
             "type": "object",
             "properties": {
@@ -909,11 +2430,84 @@ const docTemplate = `{
                 "age_to": {
                     "type": "integer"
                 },
+                "excluded_locations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "gender": {
                     "type": "string"
                 },
+                "genders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "interests_mode": {
+                    "type": "string"
+                },
                 "location": {
                     "type": "string"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rules": {
+                    "$ref": "#/definitions/domain.TargetingRule"
+                }
+            }
+        },
+        "domain.TargetingRule": {
+            "type": "object",
+            "properties": {
+                "and": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TargetingRule"
+                    }
+                },
+                "attr": {
+                    "type": "string"
+                },
+                "not": {
+                    "$ref": "#/definitions/domain.TargetingRule"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "or": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TargetingRule"
+                    }
+                },
+                "value": {
+                    "type": "integer"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.TopUpRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
                 }
             }
         },
@@ -929,6 +2523,12 @@ const docTemplate = `{
                 "gender": {
                     "type": "string"
                 },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "location": {
                     "type": "string"
                 },
@@ -962,6 +2562,12 @@ const docTemplate = `{
                 },
                 "error": {
                     "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         }
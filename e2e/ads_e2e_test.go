@@ -0,0 +1,174 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/config"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/server"
+)
+
+func TestE2EConcurrentAdsImpressionsLimit(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.ServerAddress = "127.0.0.1:0"
+	cfg.Ads.ImpressionsLimitSlack = 0
+	// Advertiser of the test doesn't top up its balance
+	cfg.Ads.EnforceBalance = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	server, err := server.NewServer(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ts := httptest.NewServer(server.HttpServer.Handler)
+	defer ts.Close()
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	// Campaign must be active on the current date
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Address,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer rdb.Close()
+	currentDate, err := repository.NewTimeRepository(rdb).GetCurrentDate(ctx)
+	if err != nil {
+		t.Fatalf("Ошибка получения текущей даты: %v", err)
+	}
+
+	// Unique location, so only clients of this test match the campaign
+	location := "e2e-" + uuid.NewString()
+
+	const (
+		impressionsLimit  = 5
+		clientsCount      = 20
+		requestsPerClient = 10
+	)
+
+	advertiserID := uuid.New()
+	reqBody := fmt.Sprintf(`[{"advertiser_id": "%s", "name": "Конкурентный рекламодатель"}]`, advertiserID)
+	resp, err := client.Post(ts.URL+"/advertisers/bulk", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на создание рекламодателя: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Ожидался статус 201 при создании рекламодателя, а получил %d", resp.StatusCode)
+	}
+
+	clientIDs := make([]uuid.UUID, clientsCount)
+	clients := make([]string, clientsCount)
+	for i := range clientIDs {
+		clientIDs[i] = uuid.New()
+		clients[i] = fmt.Sprintf(`{"client_id": "%s", "login": "e2e%d", "age": 20, "location": "%s", "gender": "MALE"}`,
+			clientIDs[i], i, location)
+	}
+	resp, err = client.Post(ts.URL+"/clients/bulk", "application/json", strings.NewReader("["+strings.Join(clients, ",")+"]"))
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на создание клиентов: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Ожидался статус 201 при создании клиентов, а получил %d", resp.StatusCode)
+	}
+
+	reqBody = fmt.Sprintf(`{
+		"ad_title": "Конкурентная кампания",
+		"ad_text": "Лимит показов должен соблюдаться",
+		"impressions_limit": %d,
+		"clicks_limit": 1,
+		"cost_per_impression": 1000,
+		"cost_per_click": 1000,
+		"start_date": %d,
+		"end_date": %d,
+		"frequency_cap_total": 0,
+		"targeting": {
+			"location": "%s"
+		}
+	}`, impressionsLimit, *currentDate, *currentDate, location)
+	resp, err = client.Post(ts.URL+"/advertisers/"+advertiserID.String()+"/campaigns", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на создание кампании: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("Ошибка чтения тела ответа при создании кампании: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Ожидался статус 201 при создании кампании, а получил %d: %s", resp.StatusCode, string(body))
+	}
+
+	var campaign domain.Campaign
+	if err := json.Unmarshal(body, &campaign); err != nil {
+		t.Fatalf("Ошибка при парсинге тела ответа при создании кампании: %v", err)
+	}
+
+	var shown atomic.Int64
+	var wg sync.WaitGroup
+	for _, clientID := range clientIDs {
+		for range requestsPerClient {
+			wg.Add(1)
+			go func(clientID uuid.UUID) {
+				defer wg.Done()
+
+				resp, err := client.Get(ts.URL + "/ads?client_id=" + clientID.String())
+				if err != nil {
+					t.Errorf("Ошибка при выполнении запроса на получение рекламы: %v", err)
+					return
+				}
+				defer resp.Body.Close()
+
+				if resp.StatusCode != http.StatusOK {
+					return
+				}
+				var ad domain.UserAd
+				if err := json.NewDecoder(resp.Body).Decode(&ad); err != nil {
+					t.Errorf("Ошибка при парсинге рекламы: %v", err)
+					return
+				}
+				if ad.AdId == campaign.ID {
+					shown.Add(1)
+				}
+			}(clientID)
+		}
+	}
+	wg.Wait()
+
+	if shown.Load() != impressionsLimit {
+		t.Fatalf("Ожидалось %d показов кампании, а получили %d", impressionsLimit, shown.Load())
+	}
+
+	respStats, err := client.Get(ts.URL + "/stats/campaigns/" + campaign.ID.String())
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на получение статистики: %v", err)
+	}
+	defer respStats.Body.Close()
+
+	var stats domain.CampaignStats
+	if err := json.NewDecoder(respStats.Body).Decode(&stats); err != nil {
+		t.Fatalf("Ошибка при парсинге статистики: %v", err)
+	}
+	if stats.ImpressionsCount != impressionsLimit {
+		t.Fatalf("Ожидалось %d показов в статистике, а получили %d", impressionsLimit, stats.ImpressionsCount)
+	}
+
+	t.Log("Тест соблюдения лимита показов при конкурентных запросах прошел успешно!")
+}
@@ -0,0 +1,123 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/config"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/server"
+)
+
+func TestE2EDeleteAudienceInUse(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.ServerAddress = "127.0.0.1:0"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	server, err := server.NewServer(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ts := httptest.NewServer(server.HttpServer.Handler)
+	defer ts.Close()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	advertiserID := uuid.New()
+	reqBody := fmt.Sprintf(`[{"advertiser_id": "%s", "name": "Рекламодатель с аудиторией"}]`, advertiserID)
+	resp, err := client.Post(ts.URL+"/advertisers/bulk", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на создание рекламодателя: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Ожидался статус 201 при создании рекламодателя, а получил %d", resp.StatusCode)
+	}
+	advertiserURL := ts.URL + "/advertisers/" + advertiserID.String()
+
+	resp, err = client.Post(advertiserURL+"/audiences", "application/json",
+		strings.NewReader(`{"name": "Москва", "targeting": {"location": "Moscow"}}`))
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на создание аудитории: %v", err)
+	}
+	var audience domain.Audience
+	decodeBody(t, resp, http.StatusCreated, &audience)
+
+	reqBody = fmt.Sprintf(`{
+		"ad_title": "Кампания с аудиторией",
+		"ad_text": "Аудиторию нельзя удалить, пока кампания не в архиве",
+		"impressions_limit": 10,
+		"clicks_limit": 1,
+		"cost_per_impression": 1,
+		"cost_per_click": 1,
+		"start_date": 1000,
+		"end_date": 1001,
+		"audience_id": "%s"
+	}`, audience.ID)
+	resp, err = client.Post(advertiserURL+"/campaigns", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на создание кампании: %v", err)
+	}
+	var campaign domain.Campaign
+	decodeBody(t, resp, http.StatusCreated, &campaign)
+
+	audienceURL := advertiserURL + "/audiences/" + audience.ID.String()
+	if status := deleteRequest(t, client, audienceURL); status != http.StatusConflict {
+		t.Fatalf("Ожидался статус 409 при удалении используемой аудитории, а получили %d", status)
+	}
+
+	resp, err = client.Post(advertiserURL+"/campaigns/"+campaign.ID.String()+"/archive", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на архивацию кампании: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Ожидался статус 200 при архивации кампании, а получили %d", resp.StatusCode)
+	}
+
+	if status := deleteRequest(t, client, audienceURL); status != http.StatusNoContent {
+		t.Fatalf("Ожидался статус 204 при удалении аудитории архивной кампании, а получили %d", status)
+	}
+
+	t.Log("Тест удаления используемой аудитории прошел успешно!")
+}
+
+func decodeBody(t *testing.T, resp *http.Response, expectedStatus int, v any) {
+	t.Helper()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Ошибка чтения тела ответа: %v", err)
+	}
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("Ожидался статус %d, а получили %d: %s", expectedStatus, resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("Ошибка при парсинге тела ответа: %v", err)
+	}
+}
+
+func deleteRequest(t *testing.T, client *http.Client, url string) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		t.Fatalf("Ошибка создания запроса на удаление: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Ошибка при выполнении запроса на удаление: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
@@ -2,34 +2,92 @@ package app
 
 import (
 	"context"
+	"log"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/config"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
 )
 
 type AdsService struct {
-	repo         repository.AdsRepository
-	userRepo     repository.UserRepository
-	campaignRepo repository.CampaignRepository
-	timeRepo     repository.TimeRepository
+	repo           repository.AdsRepository
+	userRepo       repository.UserRepository
+	campaignRepo   repository.CampaignRepository
+	advertiserRepo repository.AdvertiserRepository
+	timeRepo       repository.TimeRepository
+	counterRepo    repository.CounterRepository
+	selector       AdSelector
+	ranker         AdRanker
+	cfg            config.AdsConfig
 }
 
 func NewAdsService(repo repository.AdsRepository,
 	userRepo repository.UserRepository,
 	campaignRepo repository.CampaignRepository,
-	timeRepo repository.TimeRepository) *AdsService {
+	advertiserRepo repository.AdvertiserRepository,
+	timeRepo repository.TimeRepository,
+	counterRepo repository.CounterRepository,
+	selector AdSelector,
+	ranker AdRanker,
+	cfg config.AdsConfig) *AdsService {
 	return &AdsService{
-		repo:         repo,
-		userRepo:     userRepo,
-		campaignRepo: campaignRepo,
-		timeRepo:     timeRepo,
+		repo:           repo,
+		userRepo:       userRepo,
+		campaignRepo:   campaignRepo,
+		advertiserRepo: advertiserRepo,
+		timeRepo:       timeRepo,
+		counterRepo:    counterRepo,
+		selector:       selector,
+		ranker:         ranker,
+		cfg:            cfg,
 	}
 }
 
+// SyncCounters loads impressions, clicks, spent money counters and advertiser balances from the database
+// if redis doesn't have them yet (e.g. after redis was flushed). Stale counters are removed afterwards
+func (s *AdsService) SyncCounters(ctx context.Context) error {
+	synced, err := s.counterRepo.IsSynced(ctx)
+	if err != nil {
+		return err
+	}
+	if !synced {
+		if err := s.loadCounters(ctx); err != nil {
+			return err
+		}
+	}
+
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return err
+	}
+	return deleteStaleCounters(ctx, s.campaignRepo, s.counterRepo, int32(*currentDate))
+}
+
+func (s *AdsService) loadCounters(ctx context.Context) error {
+
+	impressions, err := s.repo.GetImpressionsCounters(ctx)
+	if err != nil {
+		return err
+	}
+	clicks, err := s.repo.GetBilledClicksCounters(ctx)
+	if err != nil {
+		return err
+	}
+	spent, err := s.repo.GetSpentCounters(ctx)
+	if err != nil {
+		return err
+	}
+	balances, err := s.advertiserRepo.GetBalances(ctx)
+	if err != nil {
+		return err
+	}
+	return s.counterRepo.Sync(ctx, impressions, clicks, spent, balances)
+}
+
 func (s *AdsService) GetAd(ctx context.Context, clientId uuid.UUID) (*domain.UserAd, error) {
-	_, err := s.userRepo.GetByID(ctx, clientId)
+	client, err := s.userRepo.GetByID(ctx, clientId)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrUserNotFound
 	} else if err != nil {
@@ -41,15 +99,33 @@ func (s *AdsService) GetAd(ctx context.Context, clientId uuid.UUID) (*domain.Use
 		return nil, err
 	}
 
-	ad, err := s.repo.GetRelativeAd(ctx, clientId, int32(*currentDate))
-	if err == pgx.ErrNoRows {
-		return nil, domain.ErrAdNotFound
+	candidates, err := s.selector.Candidates(ctx, client, int32(*currentDate))
+	if err != nil {
+		return nil, err
+	}
+	s.ranker.Rank(candidates)
+	candidate, err := s.reserveAd(ctx, candidates, clientId, int32(*currentDate))
+	if err != nil {
+		return nil, err
 	}
-	err = s.repo.Impression(ctx, ad.AdId, clientId)
+
+	err = s.repo.Impression(ctx, candidate.Campaign.ID, clientId, int32(*currentDate), s.cfg.EnforceBalance)
 	if err != nil {
+		if releaseErr := s.counterRepo.ReleaseImpression(ctx, candidate.Campaign, clientId, int32(*currentDate)); releaseErr != nil {
+			log.Printf("[INTERNAL ERROR] failed to release impression: %v", releaseErr)
+		}
+		// Balance in redis can be ahead of the database, the ad is not shown then
+		if err == domain.ErrInsufficientBalance {
+			return nil, domain.ErrAdNotFound
+		}
 		return nil, err
 	}
-	return ad, nil
+	return &domain.UserAd{
+		AdId:         candidate.Campaign.ID,
+		AdTitle:      candidate.Campaign.AdTitle,
+		AdText:       candidate.Campaign.AdText,
+		AdvertiserID: candidate.Campaign.AdvertiserID,
+	}, nil
 }
 
 func (s *AdsService) Click(ctx context.Context, adId, clientId uuid.UUID) error {
@@ -62,12 +138,95 @@ func (s *AdsService) Click(ctx context.Context, adId, clientId uuid.UUID) error
 	}
 
 	// Check if ad exists
-	_, err = s.campaignRepo.GetCampaignByID(ctx, adId)
+	campaign, err := s.campaignRepo.GetCampaignByID(ctx, adId)
 	if err == pgx.ErrNoRows {
 		return domain.ErrAdNotFound
 	} else if err != nil {
 		return err
 	}
 
-	return s.repo.Click(ctx, adId, clientId)
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return err
+	}
+
+	cost, billed, err := s.repo.Click(ctx, adId, clientId, int32(*currentDate), s.cfg.EnforceBalance)
+	if err != nil {
+		return err
+	}
+	if billed {
+		return s.counterRepo.IncrBilledClick(ctx, *campaign, cost, int32(*currentDate))
+	}
+	return nil
+}
+
+// deleteStaleCounters removes counters of past days and finished campaigns from redis
+func deleteStaleCounters(ctx context.Context,
+	campaignRepo repository.CampaignRepository,
+	counterRepo repository.CounterRepository,
+	currentDate int32) error {
+	campaignIDs, err := campaignRepo.GetUnfinishedCampaignIDs(ctx, currentDate)
+	if err != nil {
+		return err
+	}
+	return counterRepo.DeleteStale(ctx, currentDate, campaignIDs)
+}
+
+// reserveAd picks the best candidate and reserves its impression.
+// Counters could change since candidates were selected, so if the reservation
+// fails the candidate is dropped and the next one is tried
+func (s *AdsService) reserveAd(ctx context.Context, candidates []domain.AdCandidate, clientId uuid.UUID, currentDate int32) (*domain.AdCandidate, error) {
+	for {
+		candidate := selectAd(candidates)
+		if candidate == nil {
+			return nil, domain.ErrAdNotFound
+		}
+
+		maxImpressions := float64(candidate.Campaign.ImpressionsLimit) * (1 + s.cfg.ImpressionsLimitSlack)
+		reserved, err := s.counterRepo.ReserveImpression(ctx, candidate.Campaign, clientId, currentDate,
+			maxImpressions, candidate.PacingLimit, s.cfg.EnforceBalance)
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return candidate, nil
+		}
+
+		candidates = removeCandidate(candidates, candidate.Campaign.ID)
+	}
+}
+
+// removeCandidate returns a copy of candidates without the given campaign
+func removeCandidate(candidates []domain.AdCandidate, campaignID uuid.UUID) []domain.AdCandidate {
+	result := make([]domain.AdCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Campaign.ID != campaignID {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// selectAd picks the first ranked candidate that is still within its impressions limit.
+// Candidates over the limit (but within the configured slack) are picked only
+// if they bring more revenue than the best candidate within the limit
+func selectAd(candidates []domain.AdCandidate) *domain.AdCandidate {
+	var best, bestOvershoot *domain.AdCandidate
+	for i := range candidates {
+		candidate := &candidates[i]
+		if candidate.ImpressionsCount < candidate.Campaign.ImpressionsLimit {
+			if best == nil {
+				best = candidate
+			}
+			continue
+		}
+		if bestOvershoot == nil || candidate.ExpectedRevenue > bestOvershoot.ExpectedRevenue {
+			bestOvershoot = candidate
+		}
+	}
+
+	if bestOvershoot != nil && (best == nil || bestOvershoot.ExpectedRevenue > best.ExpectedRevenue) {
+		return bestOvershoot
+	}
+	return best
 }
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestSelectAd(t *testing.T) {
+	withinLimit := domain.AdCandidate{
+		Campaign: domain.Campaign{
+			ID:                uuid.New(),
+			ImpressionsLimit:  10,
+			CostPerImpression: 1,
+		},
+		Score:            100,
+		ImpressionsCount: 5,
+		ExpectedRevenue:  1,
+	}
+	cheapOvershoot := domain.AdCandidate{
+		Campaign: domain.Campaign{
+			ID:                uuid.New(),
+			ImpressionsLimit:  10,
+			CostPerImpression: 0.5,
+		},
+		Score:            200,
+		ImpressionsCount: 10,
+		ExpectedRevenue:  0.5,
+	}
+	expensiveOvershoot := domain.AdCandidate{
+		Campaign: domain.Campaign{
+			ID:                uuid.New(),
+			ImpressionsLimit:  10,
+			CostPerImpression: 3,
+		},
+		Score:            50,
+		ImpressionsCount: 10,
+		ExpectedRevenue:  3,
+	}
+
+	if ad := selectAd([]domain.AdCandidate{}); ad != nil {
+		t.Fatalf("Без кандидатов выбрана реклама %v", ad.Campaign.ID)
+	}
+
+	ad := selectAd([]domain.AdCandidate{cheapOvershoot, withinLimit})
+	if ad == nil || ad.Campaign.ID != withinLimit.Campaign.ID {
+		t.Fatalf("Ожидалась кампания в пределах лимита")
+	}
+
+	ad = selectAd([]domain.AdCandidate{withinLimit, expensiveOvershoot})
+	if ad == nil || ad.Campaign.ID != expensiveOvershoot.Campaign.ID {
+		t.Fatalf("Ожидалась более выгодная кампания сверх лимита")
+	}
+
+	ad = selectAd([]domain.AdCandidate{cheapOvershoot})
+	if ad == nil || ad.Campaign.ID != cheapOvershoot.Campaign.ID {
+		t.Fatalf("Ожидалась кампания сверх лимита, когда других кандидатов нет")
+	}
+
+	t.Log("Тест выбора рекламы пройден успешно!")
+}
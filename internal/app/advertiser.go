@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"log"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -10,15 +11,21 @@ import (
 )
 
 type AdvertiserService struct {
-	repo     repository.AdvertiserRepository
-	userRepo repository.UserRepository
+	repo        repository.AdvertiserRepository
+	userRepo    repository.UserRepository
+	timeRepo    repository.TimeRepository
+	counterRepo repository.CounterRepository
 }
 
 func NewAdvertiserService(repo repository.AdvertiserRepository,
-	userRepo repository.UserRepository) *AdvertiserService {
+	userRepo repository.UserRepository,
+	timeRepo repository.TimeRepository,
+	counterRepo repository.CounterRepository) *AdvertiserService {
 	return &AdvertiserService{
-		repo:     repo,
-		userRepo: userRepo,
+		repo:        repo,
+		userRepo:    userRepo,
+		timeRepo:    timeRepo,
+		counterRepo: counterRepo,
 	}
 }
 
@@ -39,6 +46,37 @@ func (s *AdvertiserService) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return advertiser, nil
 }
 
+func (s *AdvertiserService) TopUpBalance(ctx context.Context, id uuid.UUID, amount float64) (*domain.Advertiser, error) {
+	if amount <= 0 {
+		return nil, domain.ErrBadRequest
+	}
+
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	advertiser, err := s.repo.TopUp(ctx, id, amount, int32(*currentDate))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep balance used by ads selection in sync. Top up itself is already saved, so
+	// the error is only logged. Balance in the database is still checked on every charge
+	if err := s.counterRepo.IncrBalance(ctx, id, amount); err != nil {
+		log.Printf("[INTERNAL ERROR] failed to top up advertiser balance in redis: %v", err)
+	}
+	return advertiser, nil
+}
+
+func (s *AdvertiserService) GetLedger(ctx context.Context, id uuid.UUID, size, page int) ([]domain.LedgerEntry, error) {
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetLedger(ctx, id, size, size*page)
+}
+
 func (s *AdvertiserService) CreateUpdateMLScore(ctx context.Context, score *domain.MLScore) (*domain.MLScore, error) {
 	_, err := s.repo.GetByID(ctx, score.AdvertiserID)
 	if err != nil {
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+)
+
+type AudienceService struct {
+	repo           repository.AudienceRepository
+	advertiserRepo repository.AdvertiserRepository
+	index          *CampaignIndex
+}
+
+func NewAudienceService(repo repository.AudienceRepository,
+	advertiserRepo repository.AdvertiserRepository,
+	index *CampaignIndex) *AudienceService {
+	return &AudienceService{
+		repo:           repo,
+		advertiserRepo: advertiserRepo,
+		index:          index,
+	}
+}
+
+func (s *AudienceService) CreateAudience(ctx context.Context, advertiserID uuid.UUID, audienceRequest domain.AudienceRequest) (*domain.Audience, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+
+	if err := validateAudience(audienceRequest); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateAudience(ctx, advertiserID, audienceRequest)
+}
+
+func (s *AudienceService) GetAudiences(ctx context.Context, advertiserID uuid.UUID, size, page int) ([]domain.Audience, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	return s.repo.GetByAdvertiserID(ctx, advertiserID, size, size*page)
+}
+
+func (s *AudienceService) GetAudience(ctx context.Context, advertiserID, audienceID uuid.UUID) (*domain.Audience, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	return getAdvertiserAudience(ctx, s.repo, advertiserID, audienceID)
+}
+
+// UpdateAudience replaces the audience, changes are applied to all campaigns using it
+func (s *AudienceService) UpdateAudience(ctx context.Context, advertiserID, audienceID uuid.UUID, audienceRequest domain.AudienceRequest) (*domain.Audience, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	if _, err := getAdvertiserAudience(ctx, s.repo, advertiserID, audienceID); err != nil {
+		return nil, err
+	}
+
+	if err := validateAudience(audienceRequest); err != nil {
+		return nil, err
+	}
+
+	audience, err := s.repo.UpdateAudience(ctx, audienceID, audienceRequest)
+	if err != nil {
+		return nil, err
+	}
+	s.index.Invalidate()
+	return audience, nil
+}
+
+// DeleteAudience deletes the audience. Audience used by campaigns that are not
+// archived can't be deleted, otherwise these campaigns would target everyone
+func (s *AudienceService) DeleteAudience(ctx context.Context, advertiserID, audienceID uuid.UUID) error {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return domain.ErrAdvertiserNotFound
+	}
+	if _, err := getAdvertiserAudience(ctx, s.repo, advertiserID, audienceID); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteAudience(ctx, audienceID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domain.ErrAudienceInUse
+	}
+	s.index.Invalidate()
+	return nil
+}
+
+// getAdvertiserAudience returns the audience only if it belongs to the advertiser
+func getAdvertiserAudience(ctx context.Context, repo repository.AudienceRepository, advertiserID, audienceID uuid.UUID) (*domain.Audience, error) {
+	audience, err := repo.GetByID(ctx, audienceID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAudienceNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if audience.AdvertiserID != advertiserID {
+		return nil, domain.ErrAudienceNotFound
+	}
+	return audience, nil
+}
+
+func validateAudience(audienceRequest domain.AudienceRequest) error {
+	if audienceRequest.Name == "" {
+		return fmt.Errorf("%w: audience name can't be empty", domain.ErrBadRequest)
+	}
+	if !validateTargeting(audienceRequest.Targeting) {
+		return fmt.Errorf("%w: invalid audience targeting", domain.ErrBadRequest)
+	}
+	return nil
+}
+
+// inAudienceClients checks the audience clients list, empty list matches everyone.
+// Audience client IDs must be sorted
+func inAudienceClients(audience *domain.Audience, user *domain.User) bool {
+	if audience == nil || len(audience.ClientIDs) == 0 {
+		return true
+	}
+	_, found := slices.BinarySearchFunc(audience.ClientIDs, user.ID, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return found
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestCampaignMatcherAudience(t *testing.T) {
+	user := &domain.User{
+		ID:       uuid.New(),
+		Age:      25,
+		Location: "Moscow",
+		Gender:   "MALE",
+	}
+	moscow := "Moscow"
+	kazan := "Kazan"
+	matchAudience := func(audience *domain.Audience) bool {
+		return newCampaignMatcher(domain.Campaign{Audience: audience}).match(user)
+	}
+
+	if !matchAudience(nil) {
+		t.Fatal("Кампания без аудитории не подошла клиенту")
+	}
+
+	if !matchAudience(&domain.Audience{Targeting: domain.Targeting{Location: &moscow}}) {
+		t.Fatal("Аудитория с подходящим таргетингом не подошла клиенту")
+	}
+
+	if matchAudience(&domain.Audience{Targeting: domain.Targeting{Location: &kazan}}) {
+		t.Fatal("Аудитория с другой локацией подошла клиенту")
+	}
+
+	// Client IDs are sorted the same way as they are loaded from the database
+	clientIDs := []uuid.UUID{uuid.New(), uuid.New(), user.ID, uuid.New()}
+	slices.SortFunc(clientIDs, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	if !matchAudience(&domain.Audience{ClientIDs: clientIDs}) {
+		t.Fatal("Клиент из списка аудитории не подошел аудитории")
+	}
+
+	otherIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	slices.SortFunc(otherIDs, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	if matchAudience(&domain.Audience{ClientIDs: otherIDs}) {
+		t.Fatal("Клиент не из списка аудитории подошел аудитории")
+	}
+
+	if matchAudience(&domain.Audience{Targeting: domain.Targeting{Location: &kazan}, ClientIDs: clientIDs}) {
+		t.Fatal("Клиент из списка с неподходящим таргетингом подошел аудитории")
+	}
+
+	t.Log("Тест проверки аудитории пройден успешно!")
+}
+
+func TestValidateAudience(t *testing.T) {
+	if err := validateAudience(domain.AudienceRequest{Name: "Москвичи"}); err != nil {
+		t.Fatalf("Корректная аудитория не прошла проверку: %v", err)
+	}
+
+	if err := validateAudience(domain.AudienceRequest{}); !errors.Is(err, domain.ErrBadRequest) {
+		t.Fatal("Аудитория без названия прошла проверку")
+	}
+
+	if err := validateAudience(domain.AudienceRequest{Name: "Все", Targeting: domain.Targeting{Genders: []string{"UNKNOWN"}}}); !errors.Is(err, domain.ErrBadRequest) {
+		t.Fatal("Аудитория с некорректным таргетингом прошла проверку")
+	}
+
+	t.Log("Тест проверки аудитории при создании пройден успешно!")
+}
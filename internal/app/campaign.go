@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"fmt"
+	"math"
 	"path/filepath"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,28 +16,37 @@ import (
 type CampaignService struct {
 	repo            repository.CampaignRepository
 	advertiserRepo  repository.AdvertiserRepository
+	audienceRepo    repository.AudienceRepository
 	timeRepo        repository.TimeRepository
 	openAIService   domain.MLService
 	mlRepository    repository.MLRepository
 	fileRepo        repository.FileRepository
 	minioPublicHost string
+	allowPurge      bool
+	index           *CampaignIndex
 }
 
 func NewCampaignService(repo repository.CampaignRepository,
 	advertiserRepo repository.AdvertiserRepository,
+	audienceRepo repository.AudienceRepository,
 	timeRepo repository.TimeRepository,
 	openAIService domain.MLService,
 	mlRepository repository.MLRepository,
 	fileRepo repository.FileRepository,
-	minioPublicHost string) *CampaignService {
+	minioPublicHost string,
+	allowPurge bool,
+	index *CampaignIndex) *CampaignService {
 	return &CampaignService{
 		repo:            repo,
 		advertiserRepo:  advertiserRepo,
+		audienceRepo:    audienceRepo,
 		timeRepo:        timeRepo,
 		openAIService:   openAIService,
 		mlRepository:    mlRepository,
 		fileRepo:        fileRepo,
 		minioPublicHost: minioPublicHost,
+		allowPurge:      allowPurge,
+		index:           index,
 	}
 }
 
@@ -53,6 +64,33 @@ func (s *CampaignService) CreateCampaign(ctx context.Context, advertiserID uuid.
 		return nil, domain.ErrBadRequest
 	}
 
+	if !validateFrequencyCap(campaignRequest.FrequencyCapTotal, campaignRequest.FrequencyCapDaily) {
+		return nil, domain.ErrBadRequest
+	}
+	if campaignRequest.FrequencyCapTotal == nil {
+		capTotal := domain.DefaultFrequencyCapTotal
+		campaignRequest.FrequencyCapTotal = &capTotal
+	}
+
+	if !validateBudget(campaignRequest.BudgetTotal, campaignRequest.BudgetDaily) {
+		return nil, domain.ErrBadRequest
+	}
+
+	if campaignRequest.Pacing != nil && !isValidPacing(*campaignRequest.Pacing) {
+		return nil, domain.ErrBadRequest
+	}
+
+	if err := s.validateCampaignAudience(ctx, advertiserID, campaignRequest.AudienceID); err != nil {
+		return nil, err
+	}
+
+	// Campaign can be created only as draft or active
+	if campaignRequest.Status != nil &&
+		*campaignRequest.Status != domain.CampaignStatusDraft &&
+		*campaignRequest.Status != domain.CampaignStatusActive {
+		return nil, domain.ErrBadRequest
+	}
+
 	if err := s.validateModeration(ctx, campaignRequest.AdTitle, campaignRequest.AdText); err != nil {
 		return nil, err
 	}
@@ -65,6 +103,8 @@ func (s *CampaignService) CreateCampaign(ctx context.Context, advertiserID uuid.
 	if err != nil {
 		return nil, err
 	}
+	s.index.Invalidate()
+	campaign.Status = campaignStatus(*campaign, int32(*currentDate))
 	return campaign, nil
 }
 
@@ -104,7 +144,12 @@ func (s *CampaignService) GetCampaignsByAdvertiserID(ctx context.Context, advert
 	if err != nil {
 		return []domain.Campaign{}, err
 	}
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return []domain.Campaign{}, err
+	}
 	for i := range campaigns {
+		campaigns[i].Status = campaignStatus(campaigns[i], int32(*currentDate))
 		picURL, err := s.getPicURL(ctx, campaigns[i].ID)
 		if err == nil && picURL != "" {
 			// Set campaign pic url
@@ -122,7 +167,25 @@ func (s *CampaignService) GetCampaignByID(ctx context.Context, advertiserID, cam
 	campaign, err := s.repo.GetCampaignByID(ctx, campaignID)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	campaign.Status = campaignStatus(*campaign, int32(*currentDate))
+
+	if campaign.BudgetTotal != 0 || campaign.BudgetDaily != 0 {
+		spentTotal, spentDaily, err := s.repo.GetCampaignSpent(ctx, campaignID, int32(*currentDate))
+		if err != nil {
+			return nil, err
+		}
+		campaign.RemainingBudgetTotal = remainingBudget(campaign.BudgetTotal, spentTotal)
+		campaign.RemainingBudgetDaily = remainingBudget(campaign.BudgetDaily, spentDaily)
 	}
+
 	picURL, err := s.getPicURL(ctx, campaignID)
 	if err == nil && picURL != "" {
 		// Set campaign pic url
@@ -138,7 +201,7 @@ func (s *CampaignService) UpdateCampaign(ctx context.Context, advertiserID, camp
 		return nil, domain.ErrAdvertiserNotFound
 	}
 	// Check if campaign exists
-	_, err = s.repo.GetCampaignByID(ctx, campaignID)
+	existingCampaign, err := s.repo.GetCampaignByID(ctx, campaignID)
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrAdNotFound
 	} else if err != nil {
@@ -150,6 +213,26 @@ func (s *CampaignService) UpdateCampaign(ctx context.Context, advertiserID, camp
 		return nil, domain.ErrBadRequest
 	}
 
+	if !validateFrequencyCap(campaignUpdate.FrequencyCapTotal, campaignUpdate.FrequencyCapDaily) {
+		return nil, domain.ErrBadRequest
+	}
+
+	if !validateBudget(campaignUpdate.BudgetTotal, campaignUpdate.BudgetDaily) {
+		return nil, domain.ErrBadRequest
+	}
+
+	if campaignUpdate.Pacing != nil && !isValidPacing(*campaignUpdate.Pacing) {
+		return nil, domain.ErrBadRequest
+	}
+
+	if campaignUpdate.EndDate < campaignUpdate.StartDate {
+		return nil, domain.ErrBadRequest
+	}
+
+	if err := s.validateCampaignAudience(ctx, advertiserID, campaignUpdate.AudienceID); err != nil {
+		return nil, err
+	}
+
 	if err := s.validateModeration(ctx, campaignUpdate.AdTitle, campaignUpdate.AdText); err != nil {
 		return nil, err
 	}
@@ -160,10 +243,16 @@ func (s *CampaignService) UpdateCampaign(ctx context.Context, advertiserID, camp
 		return nil, err
 	}
 
+	if err := checkEditable(*existingCampaign, campaignUpdate, int32(*currentDate)); err != nil {
+		return nil, err
+	}
+
 	campaign, err := s.repo.UpdateCampaign(ctx, campaignID, campaignUpdate, *currentDate)
 	if err != nil {
 		return nil, err
 	}
+	s.index.Invalidate()
+	campaign.Status = campaignStatus(*campaign, int32(*currentDate))
 
 	picURL, err := s.getPicURL(ctx, campaignID)
 	if err == nil && picURL != "" {
@@ -174,19 +263,124 @@ func (s *CampaignService) UpdateCampaign(ctx context.Context, advertiserID, camp
 	return campaign, nil
 }
 
-func (s *CampaignService) DeleteCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID) error {
+// PatchCampaign applies JSON merge patch to the campaign, omitted fields stay unchanged
+func (s *CampaignService) PatchCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID, patch []byte) (*domain.Campaign, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	// Check if campaign exists
+	campaign, err := s.repo.GetCampaignByID(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// Campaigns of other advertisers can't be patched
+	if campaign.AdvertiserID != advertiserID {
+		return nil, domain.ErrAdNotFound
+	}
+
+	campaignUpdate, err := applyCampaignPatch(*campaign, patch)
+	if err != nil {
+		return nil, err
+	}
+	return s.UpdateCampaign(ctx, advertiserID, campaignID, campaignUpdate)
+}
+
+func (s *CampaignService) PauseCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID) (*domain.Campaign, error) {
+	return s.changeStatus(ctx, advertiserID, campaignID, actionPause)
+}
+
+// ResumeCampaign resumes paused campaign or launches draft
+func (s *CampaignService) ResumeCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID) (*domain.Campaign, error) {
+	return s.changeStatus(ctx, advertiserID, campaignID, actionResume)
+}
+
+func (s *CampaignService) ArchiveCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID) (*domain.Campaign, error) {
+	return s.changeStatus(ctx, advertiserID, campaignID, actionArchive)
+}
+
+func (s *CampaignService) changeStatus(ctx context.Context, advertiserID, campaignID uuid.UUID, action string) (*domain.Campaign, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	// Check if campaign exists
+	campaign, err := s.repo.GetCampaignByID(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// Status of campaigns of other advertisers can't be changed
+	if campaign.AdvertiserID != advertiserID {
+		return nil, domain.ErrAdNotFound
+	}
+
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := nextStatus(campaignStatus(*campaign, int32(*currentDate)), action)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetCampaignStatus(ctx, campaignID, status); err != nil {
+		return nil, err
+	}
+	s.index.Invalidate()
+
+	campaign.Status = status
+	picURL, err := s.getPicURL(ctx, campaignID)
+	if err == nil && picURL != "" {
+		// Set campaign pic url
+		campaign.PicURL = &picURL
+	}
+	return campaign, nil
+}
+
+// DeleteCampaign hides the campaign from listing and ad serving keeping its statistics.
+// Hard deletion also removes the campaign impressions and clicks, even if the campaign was already deleted.
+// It is an admin operation and is allowed only if enabled in config
+func (s *CampaignService) DeleteCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID, hard bool) error {
+	if hard && !s.allowPurge {
+		return domain.ErrPurgeNotAllowed
+	}
 	// Check if advertiser exists
 	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
 	if err != nil {
 		return domain.ErrAdvertiserNotFound
 	}
 	// Check if campaign exists
-	_, err = s.repo.GetCampaignByID(ctx, campaignID)
+	var campaign *domain.Campaign
+	if hard {
+		campaign, err = s.repo.GetCampaignByIDIncludingDeleted(ctx, campaignID)
+	} else {
+		campaign, err = s.repo.GetCampaignByID(ctx, campaignID)
+	}
 	if err == pgx.ErrNoRows {
 		return domain.ErrAdNotFound
+	} else if err != nil {
+		return err
 	}
-	err = s.repo.DeleteCampaign(ctx, campaignID)
-	return err
+	// Campaigns of other advertisers can't be deleted
+	if campaign.AdvertiserID != advertiserID {
+		return domain.ErrAdNotFound
+	}
+	if hard {
+		err = s.repo.PurgeCampaign(ctx, campaignID)
+	} else {
+		err = s.repo.DeleteCampaign(ctx, campaignID)
+	}
+	if err != nil {
+		return err
+	}
+	s.index.Invalidate()
+	return nil
 }
 
 func (s *CampaignService) GenerateAdText(ctx context.Context, advertiserName string, adTitle string) (string, error) {
@@ -202,6 +396,18 @@ func (s *CampaignService) checkModeration(ctx context.Context) (bool, error) {
 	return s.mlRepository.CheckModeration(ctx)
 }
 
+// validateCampaignAudience checks that the attached audience belongs to the advertiser
+func (s *CampaignService) validateCampaignAudience(ctx context.Context, advertiserID uuid.UUID, audienceID *uuid.UUID) error {
+	if audienceID == nil {
+		return nil
+	}
+	_, err := getAdvertiserAudience(ctx, s.audienceRepo, advertiserID, *audienceID)
+	if err == domain.ErrAudienceNotFound {
+		return fmt.Errorf("%w: audience not found", domain.ErrBadRequest)
+	}
+	return err
+}
+
 func (s *CampaignService) getPicURL(ctx context.Context, campaignID uuid.UUID) (string, error) {
 	// Get picture id from db
 	picID, err := s.repo.GetCampaignPicID(ctx, campaignID)
@@ -228,13 +434,49 @@ func validateTargeting(targeting domain.Targeting) bool {
 		}
 	}
 
-	if targeting.Gender != nil && !isValidGender(*targeting.Gender) {
+	for _, gender := range targetingGenders(targeting) {
+		if !isValidGender(gender) {
+			return false
+		}
+	}
+
+	// Location can't be both included and excluded
+	for _, location := range targetingLocations(targeting) {
+		if location == "" || slices.Contains(targeting.ExcludedLocations, location) {
+			return false
+		}
+	}
+	if slices.Contains(targeting.ExcludedLocations, "") {
+		return false
+	}
+
+	if slices.Contains(targeting.Interests, "") {
+		return false
+	}
+	if targeting.InterestsMode != nil &&
+		*targeting.InterestsMode != domain.InterestsModeAny &&
+		*targeting.InterestsMode != domain.InterestsModeAll {
+		return false
+	}
+
+	if targeting.Rules != nil && validateRule(*targeting.Rules, 0) != nil {
 		return false
 	}
 
 	return true
 }
 
+// validateFrequencyCap checks that caps are not negative, 0 means no cap
+func validateFrequencyCap(capTotal, capDaily *int32) bool {
+	if capTotal != nil && *capTotal < 0 {
+		return false
+	}
+	if capDaily != nil && *capDaily < 0 {
+		return false
+	}
+	return true
+}
+
 func isValidGender(gender string) bool {
 	validGenders := map[string]struct{}{
 		"MALE":   {},
@@ -262,3 +504,27 @@ func (s *CampaignService) validateModeration(ctx context.Context, adTitle, adTex
 	}
 	return nil
 }
+
+// validateBudget checks that budgets are not negative, 0 means no budget cap
+func validateBudget(budgetTotal, budgetDaily *float64) bool {
+	if budgetTotal != nil && *budgetTotal < 0 {
+		return false
+	}
+	if budgetDaily != nil && *budgetDaily < 0 {
+		return false
+	}
+	return true
+}
+
+// remainingBudget returns nil if the campaign has no budget cap
+func remainingBudget(budget, spent float64) *float64 {
+	if budget == 0 {
+		return nil
+	}
+	remaining := math.Max(math.Round((budget-spent)*100)/100, 0)
+	return &remaining
+}
+
+func isValidPacing(pacing string) bool {
+	return pacing == domain.PacingASAP || pacing == domain.PacingEven
+}
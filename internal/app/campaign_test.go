@@ -62,3 +62,47 @@ func TestValidateTargeting(t *testing.T) {
 
 	t.Log("Тест валидации таргетинга успешно пройден!")
 }
+
+func TestValidateFrequencyCap(t *testing.T) {
+	validCap := int32(3)
+	noCap := int32(0)
+	invalidCap := int32(-1)
+
+	if !validateFrequencyCap(nil, nil) {
+		t.Fatal("Незаданные ограничения частоты показов не прошли валидацию")
+	}
+	if !validateFrequencyCap(&validCap, &noCap) {
+		t.Fatal("Валидные ограничения частоты показов не прошли валидацию")
+	}
+	if validateFrequencyCap(&invalidCap, nil) {
+		t.Fatal("Отрицательное общее ограничение частоты показов прошло валидацию")
+	}
+	if validateFrequencyCap(nil, &invalidCap) {
+		t.Fatal("Отрицательное дневное ограничение частоты показов прошло валидацию")
+	}
+
+	t.Log("Тест валидации ограничений частоты показов успешно пройден!")
+}
+
+func TestRemainingBudget(t *testing.T) {
+	if remaining := remainingBudget(0, 10); remaining != nil {
+		t.Fatalf("Для кампании без бюджета получили остаток %v", *remaining)
+	}
+
+	remaining := remainingBudget(100, 30.5)
+	if remaining == nil || *remaining != 69.5 {
+		t.Fatalf("Ожидался остаток бюджета 69.5, а получили %v", remaining)
+	}
+
+	remaining = remainingBudget(10, 12)
+	if remaining == nil || *remaining != 0 {
+		t.Fatalf("Ожидался нулевой остаток при перерасходе, а получили %v", remaining)
+	}
+
+	negativeBudget := -1.0
+	if validateBudget(&negativeBudget, nil) {
+		t.Fatal("Отрицательный бюджет прошел валидацию")
+	}
+
+	t.Log("Тест остатка бюджета успешно пройден!")
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// CloneCampaign creates a draft campaign with the creative, targeting, limits and
+// picture of the source campaign and the new dates
+func (s *CampaignService) CloneCampaign(ctx context.Context, advertiserID, campaignID uuid.UUID, cloneRequest domain.CloneCampaignRequest) (*domain.Campaign, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	// Check if campaign exists
+	campaign, err := s.repo.GetCampaignByID(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// Campaigns of other advertisers can't be cloned
+	if campaign.AdvertiserID != advertiserID {
+		return nil, domain.ErrAdNotFound
+	}
+
+	// Clone refers to the same picture file
+	picID, err := s.repo.GetCampaignPicID(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone, err := s.CreateCampaign(ctx, advertiserID, cloneCampaignRequest(*campaign, cloneRequest))
+	if err != nil {
+		return nil, err
+	}
+
+	if picID != "" {
+		if err := s.repo.SetCampaignPicture(ctx, clone.ID, picID); err != nil {
+			// Don't leave a clone without the picture
+			if purgeErr := s.repo.PurgeCampaign(ctx, clone.ID); purgeErr != nil {
+				log.Printf("[INTERNAL ERROR] failed to purge campaign clone: %v", purgeErr)
+			}
+			return nil, err
+		}
+		picURL, err := s.fileRepo.GetFileLink(ctx, picID, s.minioPublicHost)
+		if err == nil && picURL != "" {
+			// Set campaign pic url
+			clone.PicURL = &picURL
+		}
+	}
+	return clone, nil
+}
+
+func cloneCampaignRequest(campaign domain.Campaign, cloneRequest domain.CloneCampaignRequest) *domain.CampaignRequest {
+	status := domain.CampaignStatusDraft
+	return &domain.CampaignRequest{
+		ImpressionsLimit:  campaign.ImpressionsLimit,
+		ClicksLimit:       campaign.ClicksLimit,
+		CostPerImpression: campaign.CostPerImpression,
+		CostPerClick:      campaign.CostPerClick,
+		AdTitle:           campaign.AdTitle,
+		AdText:            campaign.AdText,
+		StartDate:         cloneRequest.StartDate,
+		EndDate:           cloneRequest.EndDate,
+		FrequencyCapTotal: &campaign.FrequencyCapTotal,
+		FrequencyCapDaily: &campaign.FrequencyCapDaily,
+		BudgetTotal:       &campaign.BudgetTotal,
+		BudgetDaily:       &campaign.BudgetDaily,
+		Pacing:            &campaign.Pacing,
+		Status:            &status,
+		Targeting:         campaign.Targeting,
+		AudienceID:        campaign.AudienceID,
+	}
+}
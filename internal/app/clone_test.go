@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestCloneCampaignRequest(t *testing.T) {
+	location := "Moscow"
+	campaign := domain.Campaign{
+		ID:                uuid.New(),
+		ImpressionsLimit:  100,
+		ClicksLimit:       10,
+		CostPerImpression: 1,
+		CostPerClick:      2,
+		AdTitle:           "Заголовок",
+		AdText:            "Текст",
+		StartDate:         1,
+		EndDate:           5,
+		FrequencyCapTotal: 2,
+		BudgetDaily:       30,
+		Pacing:            domain.PacingEven,
+		Status:            domain.CampaignStatusActive,
+		Targeting:         domain.Targeting{Location: &location},
+	}
+
+	request := cloneCampaignRequest(campaign, domain.CloneCampaignRequest{StartDate: 10, EndDate: 20})
+
+	if request.StartDate != 10 || request.EndDate != 20 {
+		t.Fatalf("Ожидались даты 10-20, а получили %d-%d", request.StartDate, request.EndDate)
+	}
+	if request.Status == nil || *request.Status != domain.CampaignStatusDraft {
+		t.Fatal("Копия кампании должна создаваться черновиком")
+	}
+	if request.AdTitle != campaign.AdTitle || request.AdText != campaign.AdText ||
+		request.Targeting.Location == nil || *request.Targeting.Location != location {
+		t.Fatal("Креатив и таргетинг должны копироваться")
+	}
+	if request.ImpressionsLimit != 100 || *request.FrequencyCapTotal != 2 || *request.BudgetDaily != 30 || *request.Pacing != domain.PacingEven {
+		t.Fatalf("Лимиты, бюджеты и режим показа должны копироваться, а получили %+v", request)
+	}
+
+	t.Log("Тест копирования кампании пройден успешно!")
+}
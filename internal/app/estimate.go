@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+)
+
+type EstimateService struct {
+	userRepo       repository.UserRepository
+	advertiserRepo repository.AdvertiserRepository
+	audienceRepo   repository.AudienceRepository
+}
+
+func NewEstimateService(userRepo repository.UserRepository,
+	advertiserRepo repository.AdvertiserRepository,
+	audienceRepo repository.AudienceRepository) *EstimateService {
+	return &EstimateService{
+		userRepo:       userRepo,
+		advertiserRepo: advertiserRepo,
+		audienceRepo:   audienceRepo,
+	}
+}
+
+// EstimateAudience counts clients matching the targeting with the same rules as ads selection
+func (s *EstimateService) EstimateAudience(ctx context.Context, advertiserID uuid.UUID, estimateRequest domain.EstimateRequest) (*domain.AudienceEstimate, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+
+	if !validateTargeting(estimateRequest.Targeting) {
+		return nil, domain.ErrBadRequest
+	}
+
+	campaign := domain.Campaign{
+		AdvertiserID: advertiserID,
+		Targeting:    estimateRequest.Targeting,
+	}
+	if estimateRequest.AudienceID != nil {
+		audience, err := getAdvertiserAudience(ctx, s.audienceRepo, advertiserID, *estimateRequest.AudienceID)
+		if err == domain.ErrAudienceNotFound {
+			return nil, fmt.Errorf("%w: audience not found", domain.ErrBadRequest)
+		} else if err != nil {
+			return nil, err
+		}
+		campaign.Audience = audience
+	}
+
+	// Basic targeting is checked by the database, so only a part of clients is loaded
+	users, err := s.userRepo.GetByFilter(ctx, userFilter(campaign))
+	if err != nil {
+		return nil, err
+	}
+	mlScores, err := s.advertiserRepo.GetMLScoresByAdvertiserID(ctx, advertiserID)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[uuid.UUID]int32, len(mlScores))
+	for _, mlScore := range mlScores {
+		scores[mlScore.ClientID] = mlScore.Score
+	}
+
+	return estimateAudience(campaign, users, scores), nil
+}
+
+// userFilter converts the campaign and audience targeting into a database filter.
+// The filter may match more clients than the targeting (e.g. rules are not
+// converted), clients are checked with the targeting after loading
+func userFilter(campaign domain.Campaign) domain.UserFilter {
+	filter := domain.UserFilter{}
+	addTargetingToFilter(&filter, campaign.Targeting)
+	if campaign.Audience != nil {
+		addTargetingToFilter(&filter, campaign.Audience.Targeting)
+		if len(campaign.Audience.ClientIDs) > 0 {
+			filter.ClientIDs = campaign.Audience.ClientIDs
+		}
+	}
+	return filter
+}
+
+// addTargetingToFilter narrows the filter with the targeting. Lists already set
+// in the filter are kept, because the targeting is checked after loading anyway
+func addTargetingToFilter(filter *domain.UserFilter, targeting domain.Targeting) {
+	if targeting.AgeFrom != nil && (filter.AgeFrom == nil || *targeting.AgeFrom > *filter.AgeFrom) {
+		filter.AgeFrom = targeting.AgeFrom
+	}
+	if targeting.AgeTo != nil && (filter.AgeTo == nil || *targeting.AgeTo < *filter.AgeTo) {
+		filter.AgeTo = targeting.AgeTo
+	}
+	if genders := targetingGenders(targeting); len(genders) > 0 && !slices.Contains(genders, "ALL") && filter.Genders == nil {
+		filter.Genders = genders
+	}
+	if locations := targetingLocations(targeting); len(locations) > 0 && filter.Locations == nil {
+		filter.Locations = locations
+	}
+	if len(targeting.ExcludedLocations) > 0 {
+		filter.ExcludedLocations = append(filter.ExcludedLocations, targeting.ExcludedLocations...)
+	}
+	if len(targeting.Interests) > 0 && filter.InterestsAny == nil && filter.InterestsAll == nil {
+		if targeting.InterestsMode != nil && *targeting.InterestsMode == domain.InterestsModeAll {
+			filter.InterestsAll = targeting.Interests
+		} else {
+			filter.InterestsAny = targeting.Interests
+		}
+	}
+}
+
+func estimateAudience(campaign domain.Campaign, users []domain.User, scores map[uuid.UUID]int32) *domain.AudienceEstimate {
+	estimate := &domain.AudienceEstimate{}
+	var matchedScores []int32
+	matcher := newCampaignMatcher(campaign)
+	for i := range users {
+		if !matcher.match(&users[i]) {
+			continue
+		}
+		estimate.ClientsCount++
+		if score, ok := scores[users[i].ID]; ok {
+			matchedScores = append(matchedScores, score)
+		}
+	}
+
+	estimate.ScoredClientsCount = len(matchedScores)
+	if len(matchedScores) > 0 {
+		estimate.Scores = scoreDistribution(matchedScores)
+	}
+	return estimate
+}
+
+// scoreDistribution sorts the scores and returns their percentiles (nearest-rank) and average
+func scoreDistribution(scores []int32) *domain.ScoreDistribution {
+	slices.Sort(scores)
+
+	var sum int64
+	for _, score := range scores {
+		sum += int64(score)
+	}
+	percentile := func(p int) int32 {
+		rank := (p*len(scores) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return scores[rank-1]
+	}
+
+	return &domain.ScoreDistribution{
+		Min:    scores[0],
+		P25:    percentile(25),
+		Median: percentile(50),
+		P75:    percentile(75),
+		Max:    scores[len(scores)-1],
+		Avg:    float64(sum) / float64(len(scores)),
+	}
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestEstimateAudience(t *testing.T) {
+	users := []domain.User{
+		{ID: uuid.New(), Age: 20, Location: "Moscow", Gender: "MALE"},
+		{ID: uuid.New(), Age: 30, Location: "Moscow", Gender: "FEMALE"},
+		{ID: uuid.New(), Age: 40, Location: "Moscow", Gender: "MALE"},
+		{ID: uuid.New(), Age: 25, Location: "Kazan", Gender: "MALE"},
+	}
+	scores := map[uuid.UUID]int32{
+		users[0].ID: 10,
+		users[2].ID: 30,
+		users[3].ID: 100,
+	}
+	moscow := "Moscow"
+
+	estimate := estimateAudience(domain.Campaign{Targeting: domain.Targeting{Location: &moscow}}, users, scores)
+
+	if estimate.ClientsCount != 3 {
+		t.Fatalf("Ожидалось 3 подходящих клиента, а получили %d", estimate.ClientsCount)
+	}
+	if estimate.ScoredClientsCount != 2 {
+		t.Fatalf("Ожидалось 2 клиента со скором, а получили %d", estimate.ScoredClientsCount)
+	}
+	if estimate.Scores == nil || estimate.Scores.Min != 10 || estimate.Scores.Max != 30 || estimate.Scores.Avg != 20 {
+		t.Fatalf("Неверное распределение скоров: %+v", estimate.Scores)
+	}
+
+	// Estimation uses the same audience check as ads selection
+	audience := &domain.Audience{Targeting: domain.Targeting{Genders: []string{"FEMALE"}}}
+	estimate = estimateAudience(domain.Campaign{Targeting: domain.Targeting{Location: &moscow}, Audience: audience}, users, scores)
+	if estimate.ClientsCount != 1 || estimate.Scores != nil {
+		t.Fatalf("Ожидался 1 клиент без скоров, а получили %+v", estimate)
+	}
+
+	t.Log("Тест оценки аудитории пройден успешно!")
+}
+
+func TestScoreDistribution(t *testing.T) {
+	distribution := scoreDistribution([]int32{40, 10, 30, 20})
+
+	if distribution.Min != 10 || distribution.Max != 40 {
+		t.Fatalf("Ожидались минимум 10 и максимум 40, а получили %d и %d", distribution.Min, distribution.Max)
+	}
+	if distribution.P25 != 10 || distribution.Median != 20 || distribution.P75 != 30 {
+		t.Fatalf("Неверные перцентили: %+v", distribution)
+	}
+	if distribution.Avg != 25 {
+		t.Fatalf("Ожидалось среднее 25, а получили %v", distribution.Avg)
+	}
+
+	single := scoreDistribution([]int32{7})
+	if single.Min != 7 || single.P25 != 7 || single.Median != 7 || single.Max != 7 {
+		t.Fatalf("Неверное распределение одного скора: %+v", single)
+	}
+
+	t.Log("Тест распределения скоров пройден успешно!")
+}
+
+func TestUserFilter(t *testing.T) {
+	ageFrom := int32(18)
+	ageTo := int32(40)
+	audienceAgeFrom := int32(25)
+	all := "ALL"
+	modeAll := domain.InterestsModeAll
+	clientIDs := []uuid.UUID{uuid.New()}
+
+	campaign := domain.Campaign{
+		Targeting: domain.Targeting{
+			Gender:            &all,
+			AgeFrom:           &ageFrom,
+			AgeTo:             &ageTo,
+			ExcludedLocations: []string{"Kazan"},
+		},
+		Audience: &domain.Audience{
+			Targeting: domain.Targeting{
+				AgeFrom:           &audienceAgeFrom,
+				Locations:         []string{"Moscow"},
+				ExcludedLocations: []string{"Sochi"},
+				Interests:         []string{"sport"},
+				InterestsMode:     &modeAll,
+			},
+			ClientIDs: clientIDs,
+		},
+	}
+
+	filter := userFilter(campaign)
+	if *filter.AgeFrom != 25 || *filter.AgeTo != 40 {
+		t.Fatalf("Ожидался возраст от 25 до 40, а получили от %d до %d", *filter.AgeFrom, *filter.AgeTo)
+	}
+	if filter.Genders != nil {
+		t.Fatalf("Таргетинг на все полы не должен фильтровать по полу, а получили %v", filter.Genders)
+	}
+	if len(filter.Locations) != 1 || len(filter.ExcludedLocations) != 2 {
+		t.Fatalf("Неверный фильтр по локациям: %v, исключенные %v", filter.Locations, filter.ExcludedLocations)
+	}
+	if filter.InterestsAny != nil || len(filter.InterestsAll) != 1 {
+		t.Fatal("Ожидался фильтр по всем интересам аудитории")
+	}
+	if len(filter.ClientIDs) != 1 {
+		t.Fatal("Ожидался фильтр по клиентам аудитории")
+	}
+
+	if empty := userFilter(domain.Campaign{}); empty.AgeFrom != nil || empty.Locations != nil || empty.ClientIDs != nil {
+		t.Fatalf("Пустой таргетинг должен давать пустой фильтр, а получили %+v", empty)
+	}
+
+	t.Log("Тест фильтра клиентов для оценки охвата пройден успешно!")
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+)
+
+// CampaignIndex keeps active campaigns in memory, so ads selection doesn't
+// hit the database on every request. It is reloaded lazily after Invalidate
+// or when the current date changes
+type CampaignIndex struct {
+	repo         repository.CampaignRepository
+	audienceRepo repository.AudienceRepository
+
+	mu        sync.RWMutex
+	loaded    bool
+	date      int32
+	campaigns []IndexedCampaign
+}
+
+// IndexedCampaign is an active campaign with its targeting compiled once on index load
+type IndexedCampaign struct {
+	domain.Campaign
+	matcher campaignMatcher
+}
+
+// Match checks if the client fits the campaign targeting and audience
+func (c IndexedCampaign) Match(user *domain.User) bool {
+	return c.matcher.match(user)
+}
+
+func NewCampaignIndex(repo repository.CampaignRepository, audienceRepo repository.AudienceRepository) *CampaignIndex {
+	return &CampaignIndex{
+		repo:         repo,
+		audienceRepo: audienceRepo,
+	}
+}
+
+// Campaigns returns campaigns active on the current date.
+// Returned slice is shared and must not be modified
+func (i *CampaignIndex) Campaigns(ctx context.Context, currentDate int32) ([]IndexedCampaign, error) {
+	i.mu.RLock()
+	if i.loaded && i.date == currentDate {
+		campaigns := i.campaigns
+		i.mu.RUnlock()
+		return campaigns, nil
+	}
+	i.mu.RUnlock()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// Other request could have already reloaded the index
+	if i.loaded && i.date == currentDate {
+		return i.campaigns, nil
+	}
+
+	campaigns, err := i.repo.GetActiveCampaigns(ctx, currentDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.attachAudiences(ctx, campaigns); err != nil {
+		return nil, err
+	}
+	indexed := make([]IndexedCampaign, len(campaigns))
+	for j, campaign := range campaigns {
+		indexed[j] = IndexedCampaign{
+			Campaign: campaign,
+			matcher:  newCampaignMatcher(campaign),
+		}
+	}
+	i.campaigns = indexed
+	i.date = currentDate
+	i.loaded = true
+	return indexed, nil
+}
+
+// attachAudiences loads audiences of the campaigns, so audience
+// changes are applied to all campaigns using it
+func (i *CampaignIndex) attachAudiences(ctx context.Context, campaigns []domain.Campaign) error {
+	var audienceIDs []uuid.UUID
+	for _, campaign := range campaigns {
+		if campaign.AudienceID != nil && !slices.Contains(audienceIDs, *campaign.AudienceID) {
+			audienceIDs = append(audienceIDs, *campaign.AudienceID)
+		}
+	}
+	if len(audienceIDs) == 0 {
+		return nil
+	}
+
+	audiences, err := i.audienceRepo.GetByIDs(ctx, audienceIDs)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]*domain.Audience, len(audiences))
+	for j := range audiences {
+		byID[audiences[j].ID] = &audiences[j]
+	}
+	for j := range campaigns {
+		if campaigns[j].AudienceID != nil {
+			campaigns[j].Audience = byID[*campaigns[j].AudienceID]
+		}
+	}
+	return nil
+}
+
+// Invalidate marks the index as stale, it will be reloaded on the next request
+func (i *CampaignIndex) Invalidate() {
+	i.mu.Lock()
+	i.loaded = false
+	i.mu.Unlock()
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+)
+
+type InvoiceService struct {
+	repo           repository.InvoiceRepository
+	advertiserRepo repository.AdvertiserRepository
+	timeRepo       repository.TimeRepository
+}
+
+func NewInvoiceService(repo repository.InvoiceRepository,
+	advertiserRepo repository.AdvertiserRepository,
+	timeRepo repository.TimeRepository) *InvoiceService {
+	return &InvoiceService{
+		repo:           repo,
+		advertiserRepo: advertiserRepo,
+		timeRepo:       timeRepo,
+	}
+}
+
+// GetInvoice builds the invoice of the advertiser for days from..to inclusive.
+// If to is nil, the invoice is built up to the current date
+func (s *InvoiceService) GetInvoice(ctx context.Context, advertiserID uuid.UUID, from int32, to *int32) (*domain.Invoice, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if to == nil {
+		currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		currentDateInt32 := int32(*currentDate)
+		to = &currentDateInt32
+	}
+	if from < 0 || *to < from {
+		return nil, domain.ErrBadRequest
+	}
+
+	items, err := s.repo.GetInvoiceItems(ctx, advertiserID, from, *to)
+	if err != nil {
+		return nil, err
+	}
+	return buildInvoice(advertiserID, from, *to, items), nil
+}
+
+// buildInvoice counts totals of the invoice items
+func buildInvoice(advertiserID uuid.UUID, from, to int32, items []domain.InvoiceItem) *domain.Invoice {
+	invoice := &domain.Invoice{
+		AdvertiserID: advertiserID,
+		From:         from,
+		To:           to,
+		Items:        items,
+	}
+	for i := range items {
+		items[i].Total = math.Round((items[i].ImpressionsAmount+items[i].ClicksAmount)*100) / 100
+		invoice.Total += items[i].Total
+	}
+	invoice.Total = math.Round(invoice.Total*100) / 100
+	return invoice
+}
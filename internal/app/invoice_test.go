@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestBuildInvoice(t *testing.T) {
+	advertiserID := uuid.New()
+	items := []domain.InvoiceItem{
+		{
+			CampaignID:        uuid.New(),
+			ImpressionsCount:  3,
+			ImpressionsAmount: 0.3,
+			ClicksCount:       1,
+			ClicksAmount:      1.5,
+		},
+		{
+			CampaignID:        uuid.New(),
+			ImpressionsCount:  10,
+			ImpressionsAmount: 2.1,
+		},
+	}
+
+	invoice := buildInvoice(advertiserID, 1, 5, items)
+
+	if invoice.Items[0].Total != 1.8 {
+		t.Fatalf("Ожидалась сумма 1.8 по первой кампании, а получили %v", invoice.Items[0].Total)
+	}
+	if invoice.Items[1].Total != 2.1 {
+		t.Fatalf("Ожидалась сумма 2.1 по второй кампании, а получили %v", invoice.Items[1].Total)
+	}
+	if invoice.Total != 3.9 {
+		t.Fatalf("Ожидалась итоговая сумма 3.9, а получили %v", invoice.Total)
+	}
+	if invoice.From != 1 || invoice.To != 5 || invoice.AdvertiserID != advertiserID {
+		t.Fatal("Период или рекламодатель счета не совпадают с запрошенными")
+	}
+
+	if empty := buildInvoice(advertiserID, 1, 5, []domain.InvoiceItem{}); empty.Total != 0 {
+		t.Fatalf("Ожидалась нулевая сумма пустого счета, а получили %v", empty.Total)
+	}
+
+	t.Log("Тест составления счета пройден успешно!")
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"encoding/json"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// requiredCampaignFields can't be removed from the campaign by a merge patch
+var requiredCampaignFields = []string{
+	"impressions_limit",
+	"clicks_limit",
+	"cost_per_impression",
+	"cost_per_click",
+	"ad_title",
+	"ad_text",
+	"start_date",
+	"end_date",
+}
+
+// campaignFieldDefaults are the values of optional campaign fields removed by a merge patch
+var campaignFieldDefaults = map[string]any{
+	"frequency_cap_total": domain.DefaultFrequencyCapTotal,
+	"frequency_cap_daily": 0,
+	"budget_total":        0,
+	"budget_daily":        0,
+	"pacing":              domain.PacingASAP,
+}
+
+// mergePatch applies RFC 7396 JSON merge patch to the decoded JSON document
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any)
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+func campaignToUpdateRequest(campaign domain.Campaign) domain.CampaignUpdateRequest {
+	return domain.CampaignUpdateRequest{
+		ImpressionsLimit:  campaign.ImpressionsLimit,
+		ClicksLimit:       campaign.ClicksLimit,
+		CostPerImpression: campaign.CostPerImpression,
+		CostPerClick:      campaign.CostPerClick,
+		AdTitle:           campaign.AdTitle,
+		AdText:            campaign.AdText,
+		StartDate:         campaign.StartDate,
+		EndDate:           campaign.EndDate,
+		FrequencyCapTotal: &campaign.FrequencyCapTotal,
+		FrequencyCapDaily: &campaign.FrequencyCapDaily,
+		BudgetTotal:       &campaign.BudgetTotal,
+		BudgetDaily:       &campaign.BudgetDaily,
+		Pacing:            &campaign.Pacing,
+		Targeting:         campaign.Targeting,
+		AudienceID:        campaign.AudienceID,
+	}
+}
+
+// applyCampaignPatch returns the full update request for the campaign with the merge patch applied
+func applyCampaignPatch(campaign domain.Campaign, patch []byte) (domain.CampaignUpdateRequest, error) {
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return domain.CampaignUpdateRequest{}, domain.ErrBadRequest
+	}
+	if _, ok := patchDoc.(map[string]any); !ok {
+		return domain.CampaignUpdateRequest{}, domain.ErrBadRequest
+	}
+
+	current, err := json.Marshal(campaignToUpdateRequest(campaign))
+	if err != nil {
+		return domain.CampaignUpdateRequest{}, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return domain.CampaignUpdateRequest{}, err
+	}
+
+	merged := mergePatch(doc, patchDoc).(map[string]any)
+	for _, field := range requiredCampaignFields {
+		if _, ok := merged[field]; !ok {
+			return domain.CampaignUpdateRequest{}, domain.ErrBadRequest
+		}
+	}
+	for field, value := range campaignFieldDefaults {
+		if _, ok := merged[field]; !ok {
+			merged[field] = value
+		}
+	}
+
+	mergedBytes, err := json.Marshal(merged)
+	if err != nil {
+		return domain.CampaignUpdateRequest{}, err
+	}
+	var update domain.CampaignUpdateRequest
+	if err := json.Unmarshal(mergedBytes, &update); err != nil {
+		return domain.CampaignUpdateRequest{}, domain.ErrBadRequest
+	}
+	return update, nil
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		var target, patch, want any
+		json.Unmarshal([]byte(tt.target), &target)
+		json.Unmarshal([]byte(tt.patch), &patch)
+		json.Unmarshal([]byte(tt.want), &want)
+
+		got := mergePatch(target, patch)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Патч %s к %s: ожидалось %v, а получили %v", tt.patch, tt.target, want, got)
+		}
+	}
+
+	t.Log("Тест применения JSON Merge Patch пройден успешно!")
+}
+
+func TestApplyCampaignPatch(t *testing.T) {
+	gender := "MALE"
+	location := "Moscow"
+	campaign := domain.Campaign{
+		ImpressionsLimit:  100,
+		ClicksLimit:       10,
+		CostPerImpression: 1,
+		CostPerClick:      2,
+		AdTitle:           "Заголовок",
+		AdText:            "Текст",
+		StartDate:         1,
+		EndDate:           5,
+		FrequencyCapTotal: 3,
+		BudgetTotal:       50,
+		Pacing:            domain.PacingEven,
+		Targeting:         domain.Targeting{Gender: &gender, Location: &location},
+	}
+
+	update, err := applyCampaignPatch(campaign, []byte(`{"ad_text": "Новый текст", "targeting": {"location": null}, "budget_total": null}`))
+	if err != nil {
+		t.Fatalf("Неожиданная ошибка: %v", err)
+	}
+	if update.AdText != "Новый текст" || update.AdTitle != "Заголовок" || update.ImpressionsLimit != 100 {
+		t.Fatalf("Изменено должно быть только поле ad_text, а получили %+v", update)
+	}
+	if update.Targeting.Location != nil || update.Targeting.Gender == nil || *update.Targeting.Gender != gender {
+		t.Fatalf("Должна сброситься только локация в таргетинге, а получили %+v", update.Targeting)
+	}
+	if update.BudgetTotal == nil || *update.BudgetTotal != 0 {
+		t.Fatalf("Ожидался сброс budget_total в 0, а получили %v", update.BudgetTotal)
+	}
+	if update.FrequencyCapTotal == nil || *update.FrequencyCapTotal != 3 || *update.Pacing != domain.PacingEven {
+		t.Fatal("Не переданные в патче поля должны остаться прежними")
+	}
+
+	update, err = applyCampaignPatch(campaign, []byte(`{"frequency_cap_total": null}`))
+	if err != nil {
+		t.Fatalf("Неожиданная ошибка: %v", err)
+	}
+	if update.FrequencyCapTotal == nil || *update.FrequencyCapTotal != domain.DefaultFrequencyCapTotal {
+		t.Fatalf("Ожидался сброс frequency_cap_total к значению по умолчанию, а получили %v", update.FrequencyCapTotal)
+	}
+
+	for _, patch := range []string{`{"ad_title": null}`, `{"ad_text": 5}`, `[]`, `not json`} {
+		if _, err := applyCampaignPatch(campaign, []byte(patch)); !errors.Is(err, domain.ErrBadRequest) {
+			t.Fatalf("Ожидалась ошибка некорректного запроса для патча %s, а получили %v", patch, err)
+		}
+	}
+
+	t.Log("Тест частичного обновления кампании пройден успешно!")
+}
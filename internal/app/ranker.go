@@ -0,0 +1,85 @@
+package app
+
+import (
+	"sort"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// AdRanker decides in which order ad candidates should be shown
+type AdRanker interface {
+	// Rank fills expected revenue of the candidates and sorts them from the best to the worst
+	Rank(candidates []domain.AdCandidate)
+}
+
+// RevenueRanker mixes candidate relevance (ML score) and expected revenue
+// (CPI + pCTR * CPC) with the given weights. pCTR is the ML score scaled
+// against the fixed max score, so it doesn't depend on other candidates
+type RevenueRanker struct {
+	relevanceWeight float64
+	revenueWeight   float64
+	maxScore        int32
+}
+
+func NewRevenueRanker(relevanceWeight, revenueWeight float64, maxScore int32) *RevenueRanker {
+	return &RevenueRanker{
+		relevanceWeight: relevanceWeight,
+		revenueWeight:   revenueWeight,
+		maxScore:        maxScore,
+	}
+}
+
+func (r *RevenueRanker) Rank(candidates []domain.AdCandidate) {
+	if len(candidates) == 0 {
+		return
+	}
+
+	relevance := make([]float64, len(candidates))
+	var maxRevenue float64
+	for i := range candidates {
+		candidate := &candidates[i]
+		// Click probability is derived from the ML score normalized to [0, 1]
+		pCTR := normalizeScore(candidate.Score, r.maxScore)
+		relevance[i] = pCTR
+		candidate.ExpectedRevenue = candidate.Campaign.CostPerImpression + pCTR*candidate.Campaign.CostPerClick
+		maxRevenue = max(maxRevenue, candidate.ExpectedRevenue)
+	}
+
+	ranks := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		var revenue float64
+		if maxRevenue > 0 {
+			revenue = candidate.ExpectedRevenue / maxRevenue
+		}
+		ranks[i] = r.relevanceWeight*relevance[i] + r.revenueWeight*revenue
+	}
+
+	sort.Stable(rankedCandidates{candidates: candidates, ranks: ranks})
+}
+
+// normalizeScore scales the score to [0, 1], scores over the max score are capped
+func normalizeScore(score, maxScore int32) float64 {
+	if score <= 0 || maxScore <= 0 {
+		return 0
+	}
+	return min(float64(score)/float64(maxScore), 1)
+}
+
+// rankedCandidates sorts candidates by rank in descending order
+type rankedCandidates struct {
+	candidates []domain.AdCandidate
+	ranks      []float64
+}
+
+func (c rankedCandidates) Len() int {
+	return len(c.candidates)
+}
+
+func (c rankedCandidates) Less(i, j int) bool {
+	return c.ranks[i] > c.ranks[j]
+}
+
+func (c rankedCandidates) Swap(i, j int) {
+	c.candidates[i], c.candidates[j] = c.candidates[j], c.candidates[i]
+	c.ranks[i], c.ranks[j] = c.ranks[j], c.ranks[i]
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestRevenueRanker(t *testing.T) {
+	relevant := domain.AdCandidate{
+		Campaign: domain.Campaign{
+			ID:                uuid.New(),
+			CostPerImpression: 1,
+			CostPerClick:      1,
+		},
+		Score: 100,
+	}
+	profitable := domain.AdCandidate{
+		Campaign: domain.Campaign{
+			ID:                uuid.New(),
+			CostPerImpression: 1,
+			CostPerClick:      20,
+		},
+		Score: 80,
+	}
+
+	candidates := []domain.AdCandidate{relevant, profitable}
+	NewRevenueRanker(1, 0, 100).Rank(candidates)
+	if candidates[0].Campaign.ID != relevant.Campaign.ID {
+		t.Fatalf("Без учета выручки первой должна быть самая релевантная кампания")
+	}
+	if candidates[0].ExpectedRevenue != 2 {
+		t.Fatalf("Ожидалась выручка 2, а получили %v", candidates[0].ExpectedRevenue)
+	}
+
+	candidates = []domain.AdCandidate{relevant, profitable}
+	NewRevenueRanker(0.3, 0.7, 100).Rank(candidates)
+	if candidates[0].Campaign.ID != profitable.Campaign.ID {
+		t.Fatalf("С учетом выручки первой должна быть кампания с большим CPC")
+	}
+
+	t.Log("Тест ранжирования рекламы пройден успешно!")
+}
+
+func TestRevenueRankerFixedMaxScore(t *testing.T) {
+	candidate := domain.AdCandidate{
+		Campaign: domain.Campaign{
+			ID:                uuid.New(),
+			CostPerImpression: 1,
+			CostPerClick:      10,
+		},
+		Score: 50,
+	}
+
+	// Expected revenue doesn't depend on other candidates
+	alone := []domain.AdCandidate{candidate}
+	NewRevenueRanker(0.5, 0.5, 100).Rank(alone)
+	if alone[0].ExpectedRevenue != 6 {
+		t.Fatalf("Ожидалась выручка 6, а получили %v", alone[0].ExpectedRevenue)
+	}
+
+	other := candidate
+	other.Campaign.ID = uuid.New()
+	other.Score = 1000
+	withOther := []domain.AdCandidate{candidate, other}
+	NewRevenueRanker(0.5, 0.5, 100).Rank(withOther)
+	for _, ranked := range withOther {
+		if ranked.Campaign.ID == candidate.Campaign.ID && ranked.ExpectedRevenue != 6 {
+			t.Fatalf("Выручка кампании изменилась из-за других кандидатов: %v", ranked.ExpectedRevenue)
+		}
+		if ranked.Campaign.ID == other.Campaign.ID && ranked.ExpectedRevenue != 11 {
+			t.Fatalf("Скор больше максимального должен давать pCTR 1, а получили выручку %v", ranked.ExpectedRevenue)
+		}
+	}
+
+	t.Log("Тест нормировки ML скора пройден успешно!")
+}
@@ -0,0 +1,220 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// maxRuleDepth limits nesting of targeting rules
+const maxRuleDepth = 8
+
+// compileTargeting converts the targeting into a single rule: legacy fields
+// become conditions joined by AND together with the targeting rules
+func compileTargeting(targeting domain.Targeting) domain.TargetingRule {
+	var conditions []domain.TargetingRule
+
+	genders := targetingGenders(targeting)
+	if len(genders) > 0 && !slices.Contains(genders, "ALL") {
+		conditions = append(conditions, domain.TargetingRule{Attr: domain.RuleAttrGender, Op: domain.RuleOpIn, Values: genders})
+	}
+	if targeting.AgeFrom != nil {
+		conditions = append(conditions, domain.TargetingRule{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte, Value: targeting.AgeFrom})
+	}
+	if targeting.AgeTo != nil {
+		conditions = append(conditions, domain.TargetingRule{Attr: domain.RuleAttrAge, Op: domain.RuleOpLte, Value: targeting.AgeTo})
+	}
+	if locations := targetingLocations(targeting); len(locations) > 0 {
+		conditions = append(conditions, domain.TargetingRule{Attr: domain.RuleAttrLocation, Op: domain.RuleOpIn, Values: locations})
+	}
+	if len(targeting.ExcludedLocations) > 0 {
+		conditions = append(conditions, domain.TargetingRule{Attr: domain.RuleAttrLocation, Op: domain.RuleOpNotIn, Values: targeting.ExcludedLocations})
+	}
+	if len(targeting.Interests) > 0 {
+		op := domain.RuleOpAny
+		if targeting.InterestsMode != nil && *targeting.InterestsMode == domain.InterestsModeAll {
+			op = domain.RuleOpAll
+		}
+		conditions = append(conditions, domain.TargetingRule{Attr: domain.RuleAttrInterests, Op: op, Values: targeting.Interests})
+	}
+	if targeting.Rules != nil {
+		conditions = append(conditions, *targeting.Rules)
+	}
+
+	return domain.TargetingRule{And: conditions}
+}
+
+// evalRule evaluates the rule for the client, empty AND group matches everyone
+func evalRule(rule domain.TargetingRule, user *domain.User) bool {
+	if rule.Not != nil {
+		return !evalRule(*rule.Not, user)
+	}
+	if len(rule.Or) > 0 {
+		for _, child := range rule.Or {
+			if evalRule(child, user) {
+				return true
+			}
+		}
+		return false
+	}
+	if rule.Attr == "" {
+		for _, child := range rule.And {
+			if !evalRule(child, user) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch rule.Attr {
+	case domain.RuleAttrAge:
+		// Rule could be built without validation, age can't be compared without value
+		if rule.Value == nil {
+			return false
+		}
+		return compareAge(rule.Op, user.Age, *rule.Value)
+	case domain.RuleAttrGender:
+		return matchValue(rule.Op, rule.Values, user.Gender)
+	case domain.RuleAttrLocation:
+		return matchValue(rule.Op, rule.Values, user.Location)
+	case domain.RuleAttrInterests:
+		return matchInterests(rule.Op, rule.Values, user.Interests)
+	}
+	return false
+}
+
+func compareAge(op string, age, value int32) bool {
+	switch op {
+	case domain.RuleOpEq:
+		return age == value
+	case domain.RuleOpNe:
+		return age != value
+	case domain.RuleOpGte:
+		return age >= value
+	case domain.RuleOpLte:
+		return age <= value
+	}
+	return false
+}
+
+func matchValue(op string, values []string, value string) bool {
+	switch op {
+	case domain.RuleOpIn:
+		return slices.Contains(values, value)
+	case domain.RuleOpNotIn:
+		return !slices.Contains(values, value)
+	}
+	return false
+}
+
+func matchInterests(op string, values, interests []string) bool {
+	switch op {
+	case domain.RuleOpAny:
+		for _, value := range values {
+			if slices.Contains(interests, value) {
+				return true
+			}
+		}
+		return false
+	case domain.RuleOpAll:
+		for _, value := range values {
+			if !slices.Contains(interests, value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// validateRule checks that every node is either a non-empty group or a complete condition
+func validateRule(rule domain.TargetingRule, depth int) error {
+	if depth > maxRuleDepth {
+		return fmt.Errorf("rules nesting is deeper than %d", maxRuleDepth)
+	}
+
+	kinds := 0
+	if len(rule.And) > 0 {
+		kinds++
+	}
+	if len(rule.Or) > 0 {
+		kinds++
+	}
+	if rule.Not != nil {
+		kinds++
+	}
+	if rule.Attr != "" {
+		kinds++
+	}
+	if kinds != 1 {
+		return fmt.Errorf("rule must have exactly one of and, or, not, attr")
+	}
+
+	switch {
+	case len(rule.And) > 0:
+		return validateRules(rule.And, depth)
+	case len(rule.Or) > 0:
+		return validateRules(rule.Or, depth)
+	case rule.Not != nil:
+		return validateRule(*rule.Not, depth+1)
+	}
+
+	switch rule.Attr {
+	case domain.RuleAttrAge:
+		if rule.Op != domain.RuleOpEq && rule.Op != domain.RuleOpNe &&
+			rule.Op != domain.RuleOpGte && rule.Op != domain.RuleOpLte {
+			return fmt.Errorf("invalid operator %q for age", rule.Op)
+		}
+		if rule.Value == nil || len(rule.Values) > 0 {
+			return fmt.Errorf("age must be compared with value")
+		}
+		if *rule.Value < 0 || *rule.Value > 200 {
+			return fmt.Errorf("age must be between 0 and 200")
+		}
+	case domain.RuleAttrGender, domain.RuleAttrLocation:
+		if rule.Op != domain.RuleOpIn && rule.Op != domain.RuleOpNotIn {
+			return fmt.Errorf("invalid operator %q for %s", rule.Op, rule.Attr)
+		}
+		if err := validateRuleValues(rule); err != nil {
+			return err
+		}
+		if rule.Attr == domain.RuleAttrGender {
+			for _, gender := range rule.Values {
+				// Clients have no "ALL" gender, a condition on it would never match
+				if gender == "ALL" || !isValidGender(gender) {
+					return fmt.Errorf("invalid gender %q", gender)
+				}
+			}
+		}
+	case domain.RuleAttrInterests:
+		if rule.Op != domain.RuleOpAny && rule.Op != domain.RuleOpAll {
+			return fmt.Errorf("invalid operator %q for interests", rule.Op)
+		}
+		if err := validateRuleValues(rule); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown attribute %q", rule.Attr)
+	}
+	return nil
+}
+
+func validateRules(rules []domain.TargetingRule, depth int) error {
+	for _, rule := range rules {
+		if err := validateRule(rule, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRuleValues(rule domain.TargetingRule) error {
+	if rule.Value != nil || len(rule.Values) == 0 {
+		return fmt.Errorf("%s must be compared with values", rule.Attr)
+	}
+	if slices.Contains(rule.Values, "") {
+		return fmt.Errorf("%s values can't be empty", rule.Attr)
+	}
+	return nil
+}
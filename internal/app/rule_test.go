@@ -0,0 +1,132 @@
+package app
+
+import (
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestEvalRule(t *testing.T) {
+	age18 := int32(18)
+	age25 := int32(25)
+	age30 := int32(30)
+
+	// 18-25 in Moscow or 30+ anywhere
+	rule := domain.TargetingRule{Or: []domain.TargetingRule{
+		{And: []domain.TargetingRule{
+			{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte, Value: &age18},
+			{Attr: domain.RuleAttrAge, Op: domain.RuleOpLte, Value: &age25},
+			{Attr: domain.RuleAttrLocation, Op: domain.RuleOpIn, Values: []string{"Moscow"}},
+		}},
+		{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte, Value: &age30},
+	}}
+	if err := validateRule(rule, 0); err != nil {
+		t.Fatalf("Корректное правило не прошло валидацию: %v", err)
+	}
+
+	cases := []struct {
+		user     domain.User
+		expected bool
+	}{
+		{domain.User{Age: 20, Location: "Moscow"}, true},
+		{domain.User{Age: 20, Location: "Kazan"}, false},
+		{domain.User{Age: 27, Location: "Moscow"}, false},
+		{domain.User{Age: 45, Location: "Kazan"}, true},
+	}
+	for _, c := range cases {
+		if evalRule(rule, &c.user) != c.expected {
+			t.Fatalf("Неверный результат правила для клиента %+v, ожидалось %v", c.user, c.expected)
+		}
+	}
+
+	notRule := domain.TargetingRule{Not: &domain.TargetingRule{Attr: domain.RuleAttrInterests, Op: domain.RuleOpAny, Values: []string{"sport"}}}
+	if evalRule(notRule, &domain.User{Interests: []string{"sport"}}) {
+		t.Fatal("Отрицание правила подошло клиенту")
+	}
+
+	if !evalRule(domain.TargetingRule{}, &domain.User{}) {
+		t.Fatal("Пустое правило не подошло клиенту")
+	}
+
+	t.Log("Тест вычисления правил таргетинга пройден успешно!")
+}
+
+func TestValidateRule(t *testing.T) {
+	age := int32(18)
+	invalid := []domain.TargetingRule{
+		{},
+		{Attr: domain.RuleAttrAge, Op: domain.RuleOpIn, Value: &age},
+		{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte},
+		{Attr: domain.RuleAttrGender, Op: domain.RuleOpIn, Values: []string{"UNKNOWN"}},
+		{Attr: domain.RuleAttrGender, Op: domain.RuleOpIn, Values: []string{"ALL"}},
+		{Attr: domain.RuleAttrLocation, Op: domain.RuleOpNotIn},
+		{Attr: domain.RuleAttrInterests, Op: domain.RuleOpIn, Values: []string{"sport"}},
+		{Attr: "income", Op: domain.RuleOpGte, Value: &age},
+		{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte, Value: &age, Or: []domain.TargetingRule{{}}},
+	}
+	for _, rule := range invalid {
+		if validateRule(rule, 0) == nil {
+			t.Fatalf("Некорректное правило прошло валидацию: %+v", rule)
+		}
+	}
+
+	deep := domain.TargetingRule{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte, Value: &age}
+	for range maxRuleDepth + 1 {
+		deep = domain.TargetingRule{Not: &deep}
+	}
+	if validateRule(deep, 0) == nil {
+		t.Fatal("Слишком глубокое правило прошло валидацию")
+	}
+
+	t.Log("Тест валидации правил таргетинга пройден успешно!")
+}
+
+func TestCompileTargeting(t *testing.T) {
+	male := "MALE"
+	ageFrom := int32(18)
+	ageTo := int32(30)
+	targeting := domain.Targeting{
+		Gender:            &male,
+		AgeFrom:           &ageFrom,
+		AgeTo:             &ageTo,
+		Locations:         []string{"Moscow", "Kazan"},
+		ExcludedLocations: []string{"Sochi"},
+		Interests:         []string{"sport"},
+	}
+
+	users := []domain.User{
+		{Age: 25, Gender: "MALE", Location: "Moscow", Interests: []string{"sport"}},
+		{Age: 25, Gender: "FEMALE", Location: "Moscow", Interests: []string{"sport"}},
+		{Age: 17, Gender: "MALE", Location: "Kazan", Interests: []string{"sport"}},
+		{Age: 25, Gender: "MALE", Location: "Sochi", Interests: []string{"sport"}},
+		{Age: 25, Gender: "MALE", Location: "Kazan", Interests: []string{"music"}},
+	}
+	expected := []bool{true, false, false, false, false}
+
+	rule := compileTargeting(targeting)
+	if err := validateRule(rule, 0); err != nil {
+		t.Fatalf("Скомпилированный таргетинг не прошел валидацию: %v", err)
+	}
+	for i := range users {
+		if evalRule(rule, &users[i]) != expected[i] {
+			t.Fatalf("Скомпилированный таргетинг дал неверный результат для клиента %+v", users[i])
+		}
+	}
+
+	// Rules are combined with the legacy fields
+	targeting.Rules = &domain.TargetingRule{Attr: domain.RuleAttrAge, Op: domain.RuleOpNe, Value: &ageTo}
+	if matchTargeting(targeting, &domain.User{Age: 30, Gender: "MALE", Location: "Moscow", Interests: []string{"sport"}}) {
+		t.Fatal("Таргетинг подошел клиенту, не подходящему правилам")
+	}
+
+	t.Log("Тест компиляции таргетинга пройден успешно!")
+}
+
+func TestEvalRuleWithoutValue(t *testing.T) {
+	rule := domain.TargetingRule{Attr: domain.RuleAttrAge, Op: domain.RuleOpGte}
+	if evalRule(rule, &domain.User{Age: 30}) {
+		t.Fatal("Условие на возраст без значения подошло клиенту")
+	}
+
+	t.Log("Тест правила без значения пройден успешно!")
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/config"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+)
+
+// AdSelector returns ads that can be shown to the client, ranking is done separately
+type AdSelector interface {
+	Candidates(ctx context.Context, client *domain.User, currentDate int32) ([]domain.AdCandidate, error)
+}
+
+// IndexAdSelector selects candidates from the in-memory campaign index
+// and checks limits with counters stored in redis
+type IndexAdSelector struct {
+	index          *CampaignIndex
+	counterRepo    repository.CounterRepository
+	advertiserRepo repository.AdvertiserRepository
+	cfg            config.AdsConfig
+}
+
+func NewIndexAdSelector(index *CampaignIndex,
+	counterRepo repository.CounterRepository,
+	advertiserRepo repository.AdvertiserRepository,
+	cfg config.AdsConfig) *IndexAdSelector {
+	return &IndexAdSelector{
+		index:          index,
+		counterRepo:    counterRepo,
+		advertiserRepo: advertiserRepo,
+		cfg:            cfg,
+	}
+}
+
+func (s *IndexAdSelector) Candidates(ctx context.Context, client *domain.User, currentDate int32) ([]domain.AdCandidate, error) {
+	campaigns, err := s.index.Campaigns(ctx, currentDate)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]domain.Campaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		if campaign.Match(client) {
+			matched = append(matched, campaign.Campaign)
+		}
+	}
+	if len(matched) == 0 {
+		return []domain.AdCandidate{}, nil
+	}
+
+	counters, err := s.counterRepo.GetCounters(ctx, matched, client.ID, currentDate)
+	if err != nil {
+		return nil, err
+	}
+
+	mlScores, err := s.advertiserRepo.GetMLScoresByClientID(ctx, client.ID)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[uuid.UUID]int32, len(mlScores))
+	for _, mlScore := range mlScores {
+		scores[mlScore.AdvertiserID] = mlScore.Score
+	}
+
+	candidates := make([]domain.AdCandidate, 0, len(matched))
+	for i, campaign := range matched {
+		if !isEligible(campaign, counters[i], currentDate, s.cfg) {
+			continue
+		}
+		score, ok := scores[campaign.AdvertiserID]
+		if !ok {
+			score = s.cfg.DefaultMLScore
+		}
+		candidates = append(candidates, domain.AdCandidate{
+			Campaign:         campaign,
+			Score:            score,
+			ImpressionsCount: counters[i].Impressions,
+			PacingLimit:      pacingLimit(campaign, counters[i], currentDate),
+		})
+	}
+	return candidates, nil
+}
+
+// isEligible checks campaign limits, budgets, frequency caps and advertiser balance against its counters
+func isEligible(campaign domain.Campaign, counters domain.AdCounters, currentDate int32, cfg config.AdsConfig) bool {
+	if campaign.FrequencyCapTotal != 0 && counters.ClientImpressions >= int64(campaign.FrequencyCapTotal) {
+		return false
+	}
+	if campaign.FrequencyCapDaily != 0 && counters.ClientDailyImpressions >= int64(campaign.FrequencyCapDaily) {
+		return false
+	}
+	if float64(counters.Impressions) >= float64(campaign.ImpressionsLimit)*(1+cfg.ImpressionsLimitSlack) {
+		return false
+	}
+	if cfg.ClicksLimitPolicy == config.ClicksLimitStopServing && counters.BilledClicks >= campaign.ClicksLimit {
+		return false
+	}
+	if campaign.BudgetTotal != 0 && !fitsBudget(counters.Spent, campaign.CostPerImpression, campaign.BudgetTotal) {
+		return false
+	}
+	if campaign.BudgetDaily != 0 && !fitsBudget(counters.DailySpent, campaign.CostPerImpression, campaign.BudgetDaily) {
+		return false
+	}
+	if limit := pacingLimit(campaign, counters, currentDate); limit != 0 && counters.DailyImpressions >= limit {
+		return false
+	}
+	// Balance must cover the impression, clicks are checked when they are charged
+	if cfg.EnforceBalance && (counters.Balance <= 0 || counters.Balance < campaign.CostPerImpression) {
+		return false
+	}
+	return true
+}
+
+// fitsBudget checks that the budget is not exhausted and one more impression doesn't exceed it
+func fitsBudget(spent, cost, budget float64) bool {
+	return spent < budget && spent+cost <= budget
+}
+
+// pacingLimit returns how many impressions an evenly paced campaign may get on the current day:
+// impressions left at the start of the day spread over the remaining days. 0 means no limit
+func pacingLimit(campaign domain.Campaign, counters domain.AdCounters, currentDate int32) int64 {
+	if campaign.Pacing != domain.PacingEven {
+		return 0
+	}
+
+	daysRemaining := int64(campaign.EndDate - currentDate + 1)
+	if daysRemaining <= 0 {
+		return 0
+	}
+	impressionsLeft := campaign.ImpressionsLimit - (counters.Impressions - counters.DailyImpressions)
+	if impressionsLeft <= 0 {
+		// Limit is already reached, it is checked with the impressions limit itself
+		return 0
+	}
+	// Round up, so the campaign is able to spend all of its limit by the end date
+	return (impressionsLeft + daysRemaining - 1) / daysRemaining
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/config"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestPacingLimit(t *testing.T) {
+	campaign := domain.Campaign{
+		ImpressionsLimit: 100,
+		StartDate:        1,
+		EndDate:          10,
+		Pacing:           domain.PacingEven,
+	}
+
+	if limit := pacingLimit(campaign, domain.AdCounters{}, 1); limit != 10 {
+		t.Fatalf("Ожидался лимит 10 показов в первый день, а получили %d", limit)
+	}
+
+	// 40 impressions were shown before today, 60 are left for 3 days
+	counters := domain.AdCounters{Impressions: 45, DailyImpressions: 5}
+	if limit := pacingLimit(campaign, counters, 8); limit != 20 {
+		t.Fatalf("Ожидался лимит 20 показов, а получили %d", limit)
+	}
+
+	// 7 impressions left for 2 days are rounded up
+	counters = domain.AdCounters{Impressions: 93}
+	if limit := pacingLimit(campaign, counters, 9); limit != 4 {
+		t.Fatalf("Ожидался лимит 4 показа, а получили %d", limit)
+	}
+
+	campaign.Pacing = domain.PacingASAP
+	if limit := pacingLimit(campaign, domain.AdCounters{}, 1); limit != 0 {
+		t.Fatalf("Для кампании без равномерного показа получили лимит %d", limit)
+	}
+
+	t.Log("Тест равномерного распределения показов пройден успешно!")
+}
+
+func TestIsEligibleBalance(t *testing.T) {
+	campaign := domain.Campaign{
+		ImpressionsLimit: 10,
+		ClicksLimit:      10,
+	}
+	counters := domain.AdCounters{Balance: 0}
+
+	if !isEligible(campaign, counters, 1, config.AdsConfig{}) {
+		t.Fatal("Кампания с нулевым балансом не показывается без проверки баланса")
+	}
+
+	cfg := config.AdsConfig{EnforceBalance: true}
+	if isEligible(campaign, counters, 1, cfg) {
+		t.Fatal("Кампания с нулевым балансом показывается при проверке баланса")
+	}
+
+	counters.Balance = 5
+	if !isEligible(campaign, counters, 1, cfg) {
+		t.Fatal("Кампания с положительным балансом не показывается")
+	}
+
+	t.Log("Тест проверки баланса рекламодателя пройден успешно!")
+}
+
+func TestIsEligibleBudget(t *testing.T) {
+	campaign := domain.Campaign{
+		ImpressionsLimit:  10,
+		ClicksLimit:       10,
+		CostPerImpression: 3,
+		BudgetTotal:       10,
+		BudgetDaily:       5,
+	}
+
+	if !isEligible(campaign, domain.AdCounters{Spent: 7, DailySpent: 2}, 1, config.AdsConfig{}) {
+		t.Fatal("Кампания не показывается, хотя показ укладывается в бюджет")
+	}
+	if isEligible(campaign, domain.AdCounters{Spent: 8}, 1, config.AdsConfig{}) {
+		t.Fatal("Кампания показывается, хотя показ превысит общий бюджет")
+	}
+	if isEligible(campaign, domain.AdCounters{DailySpent: 3}, 1, config.AdsConfig{}) {
+		t.Fatal("Кампания показывается, хотя показ превысит дневной бюджет")
+	}
+
+	t.Log("Тест проверки бюджетов пройден успешно!")
+}
+
+func TestIsEligibleBalanceCoversCost(t *testing.T) {
+	campaign := domain.Campaign{
+		ImpressionsLimit:  10,
+		ClicksLimit:       10,
+		CostPerImpression: 2,
+		CostPerClick:      5,
+	}
+	cfg := config.AdsConfig{EnforceBalance: true}
+
+	if isEligible(campaign, domain.AdCounters{Balance: 1}, 1, cfg) {
+		t.Fatal("Кампания показывается, хотя баланса не хватает на показ")
+	}
+	if !isEligible(campaign, domain.AdCounters{Balance: 2}, 1, cfg) {
+		t.Fatal("Кампания не показывается, хотя баланса хватает на показ")
+	}
+
+	t.Log("Тест достаточности баланса пройден успешно!")
+}
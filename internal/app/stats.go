@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
+)
+
+type StatsService struct {
+	repo           repository.StatsRepository
+	campaignRepo   repository.CampaignRepository
+	advertiserRepo repository.AdvertiserRepository
+	timeRepo       repository.TimeRepository
+}
+
+func NewStatsService(repo repository.StatsRepository,
+	campaignRepo repository.CampaignRepository,
+	advertiserRepo repository.AdvertiserRepository,
+	timeRepo repository.TimeRepository) *StatsService {
+	return &StatsService{
+		repo:           repo,
+		campaignRepo:   campaignRepo,
+		advertiserRepo: advertiserRepo,
+		timeRepo:       timeRepo,
+	}
+}
+
+func (s *StatsService) GetCampaignStats(ctx context.Context, campaignID uuid.UUID) (*domain.CampaignStats, error) {
+	// Check if campaign exists, stats of deleted campaigns are kept
+	_, err := s.campaignRepo.GetCampaignByIDIncludingDeleted(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetCampaignStats(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	fillStats(&stats.Stats)
+	return stats, nil
+}
+
+func (s *StatsService) GetCampaignDailyStats(ctx context.Context, campaignID uuid.UUID) ([]domain.DailyStats, error) {
+	// Check if campaign exists, stats of deleted campaigns are kept
+	_, err := s.campaignRepo.GetCampaignByIDIncludingDeleted(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyStats, err := s.repo.GetCampaignDailyStats(ctx, campaignID, int32(*currentDate))
+	if err != nil {
+		return nil, err
+	}
+	for i := range dailyStats {
+		fillStats(&dailyStats[i].Stats)
+	}
+	return dailyStats, nil
+}
+
+func (s *StatsService) GetAdvertiserDailyStats(ctx context.Context, advertiserID uuid.UUID) ([]domain.DailyStats, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentDate, err := s.timeRepo.GetCurrentDate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyStats, err := s.repo.GetAdvertiserDailyStats(ctx, advertiserID, int32(*currentDate))
+	if err != nil {
+		return nil, err
+	}
+	for i := range dailyStats {
+		fillStats(&dailyStats[i].Stats)
+	}
+	return dailyStats, nil
+}
+
+// fillStats calculates conversion (in percents) and total spent
+// from the counters and spendings returned by the repository
+func fillStats(stats *domain.Stats) {
+	if stats.ImpressionsCount > 0 {
+		conversion := float64(stats.ClicksCount) / float64(stats.ImpressionsCount) * 100
+		stats.Conversion = math.Round(conversion*100) / 100
+	} else {
+		stats.Conversion = 0
+	}
+	stats.SpentTotal = math.Round((stats.SpentImpressions+stats.SpentClicks)*100) / 100
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestFillStats(t *testing.T) {
+	stats := domain.Stats{
+		ImpressionsCount: 3,
+		ClicksCount:      1,
+		SpentImpressions: 0.15,
+		SpentClicks:      0.2,
+	}
+	fillStats(&stats)
+
+	if stats.Conversion != 33.33 {
+		t.Fatalf("Ожидалась конверсия 33.33, а получили %v", stats.Conversion)
+	}
+	if stats.SpentTotal != 0.35 {
+		t.Fatalf("Ожидались общие траты 0.35, а получили %v", stats.SpentTotal)
+	}
+
+	emptyStats := domain.Stats{}
+	fillStats(&emptyStats)
+
+	if emptyStats.Conversion != 0 {
+		t.Fatalf("Ожидалась нулевая конверсия без показов, а получили %v", emptyStats.Conversion)
+	}
+
+	t.Log("Тест подсчета статистики пройден успешно!")
+}
@@ -0,0 +1,66 @@
+package app
+
+import "gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+
+// Campaign status actions
+const (
+	actionPause   = "pause"
+	actionResume  = "resume"
+	actionArchive = "archive"
+)
+
+// campaignStatus returns the status shown to the advertiser.
+// Campaigns are finished after their end date unless they are archived
+func campaignStatus(campaign domain.Campaign, currentDate int32) string {
+	if campaign.Status != domain.CampaignStatusArchived && campaign.EndDate < currentDate {
+		return domain.CampaignStatusFinished
+	}
+	return campaign.Status
+}
+
+// nextStatus returns the stored status after the action on the campaign with the given status
+func nextStatus(status, action string) (string, error) {
+	switch {
+	case action == actionPause && status == domain.CampaignStatusActive:
+		return domain.CampaignStatusPaused, nil
+	case action == actionResume && (status == domain.CampaignStatusPaused || status == domain.CampaignStatusDraft):
+		return domain.CampaignStatusActive, nil
+	case action == actionArchive && status != domain.CampaignStatusArchived:
+		return domain.CampaignStatusArchived, nil
+	}
+	return "", domain.ErrInvalidStatusTransition
+}
+
+// checkEditable checks that the update changes only fields editable in the campaign status:
+// drafts and not started campaigns are fully editable, limits and dates of started
+// campaigns are fixed, finished and archived campaigns are read-only
+func checkEditable(campaign domain.Campaign, update domain.CampaignUpdateRequest, currentDate int32) error {
+	switch campaignStatus(campaign, currentDate) {
+	case domain.CampaignStatusFinished, domain.CampaignStatusArchived:
+		return domain.ErrCampaignNotEditable
+	case domain.CampaignStatusDraft:
+		return nil
+	}
+
+	if campaign.StartDate > currentDate {
+		return nil
+	}
+
+	var fields []string
+	if update.ImpressionsLimit != campaign.ImpressionsLimit {
+		fields = append(fields, "impressions_limit")
+	}
+	if update.ClicksLimit != campaign.ClicksLimit {
+		fields = append(fields, "clicks_limit")
+	}
+	if update.StartDate != campaign.StartDate {
+		fields = append(fields, "start_date")
+	}
+	if update.EndDate != campaign.EndDate {
+		fields = append(fields, "end_date")
+	}
+	if len(fields) > 0 {
+		return &domain.NotEditableFieldsError{Fields: fields}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestCampaignStatus(t *testing.T) {
+	campaign := domain.Campaign{Status: domain.CampaignStatusActive, StartDate: 1, EndDate: 5}
+
+	if status := campaignStatus(campaign, 5); status != domain.CampaignStatusActive {
+		t.Fatalf("Ожидался статус active в последний день кампании, а получили %s", status)
+	}
+	if status := campaignStatus(campaign, 6); status != domain.CampaignStatusFinished {
+		t.Fatalf("Ожидался статус finished после окончания кампании, а получили %s", status)
+	}
+
+	campaign.Status = domain.CampaignStatusArchived
+	if status := campaignStatus(campaign, 6); status != domain.CampaignStatusArchived {
+		t.Fatalf("Ожидался статус archived у архивной кампании, а получили %s", status)
+	}
+
+	t.Log("Тест вычисления статуса кампании пройден успешно!")
+}
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		action string
+		want   string
+	}{
+		{domain.CampaignStatusActive, actionPause, domain.CampaignStatusPaused},
+		{domain.CampaignStatusPaused, actionResume, domain.CampaignStatusActive},
+		{domain.CampaignStatusDraft, actionResume, domain.CampaignStatusActive},
+		{domain.CampaignStatusPaused, actionArchive, domain.CampaignStatusArchived},
+		{domain.CampaignStatusFinished, actionArchive, domain.CampaignStatusArchived},
+		{domain.CampaignStatusPaused, actionPause, ""},
+		{domain.CampaignStatusActive, actionResume, ""},
+		{domain.CampaignStatusFinished, actionResume, ""},
+		{domain.CampaignStatusArchived, actionResume, ""},
+		{domain.CampaignStatusArchived, actionArchive, ""},
+	}
+
+	for _, tt := range tests {
+		got, err := nextStatus(tt.status, tt.action)
+		if tt.want == "" {
+			if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+				t.Fatalf("Ожидалась ошибка перехода %s -> %s, а получили %v", tt.status, tt.action, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Fatalf("Ожидался статус %s после %s из %s, а получили %s (%v)", tt.want, tt.action, tt.status, got, err)
+		}
+	}
+
+	t.Log("Тест переходов статусов кампании пройден успешно!")
+}
+
+func TestCheckEditable(t *testing.T) {
+	campaign := domain.Campaign{
+		Status:           domain.CampaignStatusActive,
+		ImpressionsLimit: 100,
+		ClicksLimit:      10,
+		StartDate:        2,
+		EndDate:          5,
+	}
+	update := domain.CampaignUpdateRequest{
+		ImpressionsLimit: 100,
+		ClicksLimit:      10,
+		StartDate:        2,
+		EndDate:          5,
+		AdTitle:          "Новый заголовок",
+	}
+	changedLimits := update
+	changedLimits.ImpressionsLimit = 200
+
+	if err := checkEditable(campaign, changedLimits, 1); err != nil {
+		t.Fatalf("Лимиты не начавшейся кампании должны изменяться, а получили %v", err)
+	}
+	if err := checkEditable(campaign, update, 3); err != nil {
+		t.Fatalf("Текст начавшейся кампании должен изменяться, а получили %v", err)
+	}
+	changedLimits.EndDate = 10
+	var fieldsErr *domain.NotEditableFieldsError
+	err := checkEditable(campaign, changedLimits, 3)
+	if !errors.As(err, &fieldsErr) || !errors.Is(err, domain.ErrCampaignNotEditable) {
+		t.Fatalf("Лимиты начавшейся кампании не должны изменяться, а получили %v", err)
+	}
+	if len(fieldsErr.Fields) != 2 || fieldsErr.Fields[0] != "impressions_limit" || fieldsErr.Fields[1] != "end_date" {
+		t.Fatalf("Ожидались поля impressions_limit и end_date, а получили %v", fieldsErr.Fields)
+	}
+	if err := checkEditable(campaign, update, 6); !errors.Is(err, domain.ErrCampaignNotEditable) {
+		t.Fatalf("Завершенная кампания не должна изменяться, а получили %v", err)
+	}
+
+	campaign.Status = domain.CampaignStatusDraft
+	if err := checkEditable(campaign, changedLimits, 3); err != nil {
+		t.Fatalf("Черновик должен изменяться полностью, а получили %v", err)
+	}
+
+	campaign.Status = domain.CampaignStatusArchived
+	if err := checkEditable(campaign, update, 3); !errors.Is(err, domain.ErrCampaignNotEditable) {
+		t.Fatalf("Архивная кампания не должна изменяться, а получили %v", err)
+	}
+
+	t.Log("Тест проверки изменяемости кампании пройден успешно!")
+}
@@ -0,0 +1,48 @@
+package app
+
+import "gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+
+// campaignMatcher checks clients against both the campaign targeting and its audience.
+// It is used for ads selection and audience estimation, targeting is compiled once
+// when the matcher is created, so it isn't rebuilt for every client
+type campaignMatcher struct {
+	rule     domain.TargetingRule
+	audience *domain.Audience
+}
+
+func newCampaignMatcher(campaign domain.Campaign) campaignMatcher {
+	rule := compileTargeting(campaign.Targeting)
+	if campaign.Audience != nil {
+		rule = domain.TargetingRule{And: []domain.TargetingRule{rule, compileTargeting(campaign.Audience.Targeting)}}
+	}
+	return campaignMatcher{
+		rule:     rule,
+		audience: campaign.Audience,
+	}
+}
+
+func (m campaignMatcher) match(user *domain.User) bool {
+	return evalRule(m.rule, user) && inAudienceClients(m.audience, user)
+}
+
+// matchTargeting checks if the client fits the campaign targeting,
+// empty targeting fields match everyone
+func matchTargeting(targeting domain.Targeting, user *domain.User) bool {
+	return evalRule(compileTargeting(targeting), user)
+}
+
+// targetingGenders combines the single gender with the genders list
+func targetingGenders(targeting domain.Targeting) []string {
+	if targeting.Gender == nil {
+		return targeting.Genders
+	}
+	return append([]string{*targeting.Gender}, targeting.Genders...)
+}
+
+// targetingLocations combines the single location with the locations list
+func targetingLocations(targeting domain.Targeting) []string {
+	if targeting.Location == nil {
+		return targeting.Locations
+	}
+	return append([]string{*targeting.Location}, targeting.Locations...)
+}
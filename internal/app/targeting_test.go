@@ -0,0 +1,155 @@
+package app
+
+import (
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestMatchTargeting(t *testing.T) {
+	user := &domain.User{
+		Age:      25,
+		Location: "Moscow",
+		Gender:   "MALE",
+	}
+
+	male := "MALE"
+	female := "FEMALE"
+	all := "ALL"
+	ageFrom := int32(18)
+	ageTo := int32(30)
+	oldAgeFrom := int32(40)
+	moscow := "Moscow"
+	kazan := "Kazan"
+
+	if !matchTargeting(domain.Targeting{}, user) {
+		t.Fatal("Пустой таргетинг не подошел клиенту")
+	}
+
+	if !matchTargeting(domain.Targeting{Gender: &male, AgeFrom: &ageFrom, AgeTo: &ageTo, Location: &moscow}, user) {
+		t.Fatal("Подходящий таргетинг не подошел клиенту")
+	}
+
+	if !matchTargeting(domain.Targeting{Gender: &all}, user) {
+		t.Fatal("Таргетинг на все полы не подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{Gender: &female}, user) {
+		t.Fatal("Таргетинг на другой пол подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{AgeFrom: &oldAgeFrom}, user) {
+		t.Fatal("Таргетинг на старший возраст подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{AgeTo: &ageFrom}, user) {
+		t.Fatal("Таргетинг на младший возраст подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{Location: &kazan}, user) {
+		t.Fatal("Таргетинг на другую локацию подошел клиенту")
+	}
+
+	t.Log("Тест таргетинга пройден успешно!")
+}
+
+func TestMatchTargetingLists(t *testing.T) {
+	user := &domain.User{
+		Age:      25,
+		Location: "Moscow",
+		Gender:   "MALE",
+	}
+
+	moscow := "Moscow"
+	female := "FEMALE"
+
+	if !matchTargeting(domain.Targeting{Locations: []string{"Saint Petersburg", "Moscow"}}, user) {
+		t.Fatal("Таргетинг на список локаций с локацией клиента не подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{Locations: []string{"Saint Petersburg", "Kazan"}}, user) {
+		t.Fatal("Таргетинг на список других локаций подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{ExcludedLocations: []string{"Moscow"}}, user) {
+		t.Fatal("Таргетинг с исключенной локацией клиента подошел клиенту")
+	}
+
+	if !matchTargeting(domain.Targeting{ExcludedLocations: []string{"Kazan"}}, user) {
+		t.Fatal("Таргетинг с исключенной другой локацией не подошел клиенту")
+	}
+
+	if !matchTargeting(domain.Targeting{Location: &moscow, Locations: []string{"Kazan"}}, user) {
+		t.Fatal("Одиночная локация должна объединяться со списком локаций")
+	}
+
+	if !matchTargeting(domain.Targeting{Genders: []string{"FEMALE", "MALE"}}, user) {
+		t.Fatal("Таргетинг на список полов с полом клиента не подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{Gender: &female, Genders: []string{"FEMALE"}}, user) {
+		t.Fatal("Таргетинг на другой пол подошел клиенту")
+	}
+
+	t.Log("Тест таргетинга по спискам пройден успешно!")
+}
+
+func TestValidateTargetingLists(t *testing.T) {
+	kazan := "Kazan"
+
+	if !validateTargeting(domain.Targeting{Locations: []string{"Moscow"}, ExcludedLocations: []string{"Kazan"}, Genders: []string{"MALE", "ALL"}}) {
+		t.Fatal("Корректный таргетинг по спискам не прошел проверку")
+	}
+
+	if validateTargeting(domain.Targeting{Genders: []string{"UNKNOWN"}}) {
+		t.Fatal("Неизвестный пол в списке прошел проверку")
+	}
+
+	if validateTargeting(domain.Targeting{Location: &kazan, ExcludedLocations: []string{"Kazan"}}) {
+		t.Fatal("Одна и та же локация не может быть включена и исключена")
+	}
+
+	if validateTargeting(domain.Targeting{Locations: []string{""}}) {
+		t.Fatal("Пустая локация прошла проверку")
+	}
+
+	t.Log("Тест проверки таргетинга по спискам пройден успешно!")
+}
+
+func TestMatchTargetingInterests(t *testing.T) {
+	user := &domain.User{
+		Age:       25,
+		Location:  "Moscow",
+		Gender:    "MALE",
+		Interests: []string{"sport", "music"},
+	}
+
+	modeAny := domain.InterestsModeAny
+	modeAll := domain.InterestsModeAll
+
+	if !matchTargeting(domain.Targeting{Interests: []string{"sport", "cars"}}, user) {
+		t.Fatal("Таргетинг на любой из интересов клиента не подошел клиенту")
+	}
+
+	if !matchTargeting(domain.Targeting{Interests: []string{"music"}, InterestsMode: &modeAny}, user) {
+		t.Fatal("Таргетинг в режиме any не подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{Interests: []string{"cars", "travel"}}, user) {
+		t.Fatal("Таргетинг на другие интересы подошел клиенту")
+	}
+
+	if !matchTargeting(domain.Targeting{Interests: []string{"sport", "music"}, InterestsMode: &modeAll}, user) {
+		t.Fatal("Таргетинг на все интересы клиента не подошел клиенту")
+	}
+
+	if matchTargeting(domain.Targeting{Interests: []string{"sport", "cars"}, InterestsMode: &modeAll}, user) {
+		t.Fatal("Таргетинг в режиме all подошел клиенту без одного из интересов")
+	}
+
+	if matchTargeting(domain.Targeting{Interests: []string{"sport"}}, &domain.User{Gender: "MALE"}) {
+		t.Fatal("Таргетинг по интересам подошел клиенту без интересов")
+	}
+
+	t.Log("Тест таргетинга по интересам пройден успешно!")
+}
@@ -2,20 +2,39 @@ package app
 
 import (
 	"context"
+	"log"
 
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/repository"
 )
 
 type TimeService struct {
-	repo repository.TimeRepository
+	repo         repository.TimeRepository
+	campaignRepo repository.CampaignRepository
+	counterRepo  repository.CounterRepository
+	index        *CampaignIndex
 }
 
-func NewTimeService(repo repository.TimeRepository) *TimeService {
+func NewTimeService(repo repository.TimeRepository,
+	campaignRepo repository.CampaignRepository,
+	counterRepo repository.CounterRepository,
+	index *CampaignIndex) *TimeService {
 	return &TimeService{
-		repo: repo,
+		repo:         repo,
+		campaignRepo: campaignRepo,
+		counterRepo:  counterRepo,
+		index:        index,
 	}
 }
 
 func (s *TimeService) SetCurrentDate(ctx context.Context, newDate int) error {
-	return s.repo.SetCurrentDate(ctx, newDate)
+	if err := s.repo.SetCurrentDate(ctx, newDate); err != nil {
+		return err
+	}
+	s.index.Invalidate()
+
+	// Date is already changed, stale counters are only a memory leak
+	if err := deleteStaleCounters(ctx, s.campaignRepo, s.counterRepo, int32(newDate)); err != nil {
+		log.Printf("[INTERNAL ERROR] failed to delete stale counters: %v", err)
+	}
+	return nil
 }
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
@@ -41,6 +42,9 @@ func validateUser(user *domain.User) error {
 	if !isValidGender(user.Gender) {
 		return fmt.Errorf("invalid user gender")
 	}
+	if slices.Contains(user.Interests, "") {
+		return fmt.Errorf("interest can't be empty")
+	}
 	return nil
 }
 
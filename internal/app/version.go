@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func (s *CampaignService) GetCampaignVersions(ctx context.Context, advertiserID, campaignID uuid.UUID, size, page int) ([]domain.CampaignVersion, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	// Check if campaign exists
+	campaign, err := s.repo.GetCampaignByID(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// History of campaigns of other advertisers is not available
+	if campaign.AdvertiserID != advertiserID {
+		return nil, domain.ErrAdNotFound
+	}
+
+	return s.repo.GetCampaignVersions(ctx, campaignID, size, size*page)
+}
+
+// RestoreCampaignVersion rolls back the campaign creative and targeting to the version.
+// Restored campaign is saved as a new version
+func (s *CampaignService) RestoreCampaignVersion(ctx context.Context, advertiserID, campaignID uuid.UUID, version int32) (*domain.Campaign, error) {
+	// Check if advertiser exists
+	_, err := s.advertiserRepo.GetByID(ctx, advertiserID)
+	if err != nil {
+		return nil, domain.ErrAdvertiserNotFound
+	}
+	// Check if campaign exists
+	campaign, err := s.repo.GetCampaignByID(ctx, campaignID)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// Campaigns of other advertisers can't be restored
+	if campaign.AdvertiserID != advertiserID {
+		return nil, domain.ErrAdNotFound
+	}
+
+	campaignVersion, err := s.repo.GetCampaignVersion(ctx, campaignID, version)
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrVersionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Update runs moderation of the restored creative
+	return s.UpdateCampaign(ctx, advertiserID, campaignID, restoreCreative(*campaign, campaignVersion.Campaign))
+}
+
+// restoreCreative returns the update request which keeps the campaign limits, prices,
+// dates and budgets and takes ad title, ad text and targeting from the snapshot
+func restoreCreative(campaign, snapshot domain.Campaign) domain.CampaignUpdateRequest {
+	update := campaignToUpdateRequest(campaign)
+	update.AdTitle = snapshot.AdTitle
+	update.AdText = snapshot.AdText
+	update.Targeting = snapshot.Targeting
+	return update
+}
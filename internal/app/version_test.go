@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+func TestRestoreCreative(t *testing.T) {
+	oldLocation := "Moscow"
+	newLocation := "Kazan"
+	snapshot := domain.Campaign{
+		ImpressionsLimit: 10,
+		CostPerClick:     1,
+		AdTitle:          "Старый заголовок",
+		AdText:           "Старый текст",
+		Targeting:        domain.Targeting{Location: &oldLocation},
+	}
+	campaign := domain.Campaign{
+		ImpressionsLimit: 100,
+		CostPerClick:     5,
+		AdTitle:          "Новый заголовок",
+		AdText:           "Новый текст",
+		StartDate:        3,
+		EndDate:          7,
+		BudgetTotal:      50,
+		Targeting:        domain.Targeting{Location: &newLocation},
+	}
+
+	update := restoreCreative(campaign, snapshot)
+
+	if update.AdTitle != snapshot.AdTitle || update.AdText != snapshot.AdText {
+		t.Fatalf("Ожидался откат заголовка и текста, а получили %q и %q", update.AdTitle, update.AdText)
+	}
+	if update.Targeting.Location == nil || *update.Targeting.Location != oldLocation {
+		t.Fatalf("Ожидался откат таргетинга на локацию %s", oldLocation)
+	}
+	if update.ImpressionsLimit != 100 || update.CostPerClick != 5 || update.StartDate != 3 || update.EndDate != 7 || *update.BudgetTotal != 50 {
+		t.Fatalf("Лимиты, цены, даты и бюджеты не должны откатываться, а получили %+v", update)
+	}
+
+	t.Log("Тест отката креатива кампании пройден успешно!")
+}
@@ -14,6 +14,9 @@ type Config struct {
 	Redis         RedisConfig
 	OpenAI        OpenAIConfig
 	MinIO         MinIOConfig
+	Ads           AdsConfig
+	// Allow hard deletion of campaigns with their impressions and clicks
+	AllowCampaignPurge bool
 }
 
 type RedisConfig struct {
@@ -37,6 +40,28 @@ type MinIOConfig struct {
 	PublicHost      string
 }
 
+type AdsConfig struct {
+	ClicksLimitPolicy string
+	// Allowed share of impressions over the campaign impressions limit, e.g. 0.05 is 5%
+	ImpressionsLimitSlack float64
+	// Weights of ML score relevance and expected revenue in ad ranking
+	RelevanceWeight float64
+	RevenueWeight   float64
+	// ML score used for clients without a score for the advertiser
+	DefaultMLScore int32
+	// ML score that means the highest click probability, used to normalize scores
+	MaxMLScore int32
+	// Don't show ads and don't bill clicks the advertiser balance doesn't cover
+	EnforceBalance bool
+}
+
+const (
+	// Campaign is not shown anymore when it reaches its clicks limit
+	ClicksLimitStopServing = "stop_serving"
+	// Campaign is still shown, but clicks over the limit are not billed
+	ClicksLimitStopBilling = "stop_billing"
+)
+
 func NewConfig() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -124,6 +149,74 @@ func NewConfig() *Config {
 		aiGenerationModel = "qwen2.5:3b"
 	}
 
+	clicksLimitPolicy := os.Getenv("CLICKS_LIMIT_POLICY")
+	switch clicksLimitPolicy {
+	case "":
+		log.Printf("Clicks limit policy unset, using default (%s)", ClicksLimitStopServing)
+		clicksLimitPolicy = ClicksLimitStopServing
+	case ClicksLimitStopServing, ClicksLimitStopBilling:
+	default:
+		log.Fatalf("invalid CLICKS_LIMIT_POLICY, must be %s or %s", ClicksLimitStopServing, ClicksLimitStopBilling)
+	}
+
+	var impressionsLimitSlack float64
+	impressionsLimitSlackStr := os.Getenv("IMPRESSIONS_LIMIT_SLACK")
+	if impressionsLimitSlackStr == "" {
+		log.Println("Impressions limit slack unset, using default (0)")
+	} else {
+		impressionsLimitSlack, err = strconv.ParseFloat(impressionsLimitSlackStr, 64)
+		if err != nil || impressionsLimitSlack < 0 {
+			log.Fatalln("IMPRESSIONS_LIMIT_SLACK must be a non-negative number")
+		}
+	}
+
+	relevanceWeight := parseWeight("RANKING_RELEVANCE_WEIGHT", 0.5)
+	revenueWeight := parseWeight("RANKING_REVENUE_WEIGHT", 0.5)
+
+	var defaultMLScore int
+	defaultMLScoreStr := os.Getenv("ML_DEFAULT_SCORE")
+	if defaultMLScoreStr == "" {
+		log.Println("Default ML score unset, using default (0)")
+	} else {
+		defaultMLScore, err = strconv.Atoi(defaultMLScoreStr)
+		if err != nil {
+			log.Fatalln("failed to convert default ML score to integer")
+		}
+	}
+
+	maxMLScore := 100
+	maxMLScoreStr := os.Getenv("ML_MAX_SCORE")
+	if maxMLScoreStr == "" {
+		log.Println("Max ML score unset, using default (100)")
+	} else {
+		maxMLScore, err = strconv.Atoi(maxMLScoreStr)
+		if err != nil || maxMLScore <= 0 {
+			log.Fatalln("ML_MAX_SCORE must be a positive integer")
+		}
+	}
+
+	enforceBalance := true
+	enforceBalanceStr := os.Getenv("BILLING_ENFORCE_BALANCE")
+	if enforceBalanceStr == "" {
+		log.Println("Billing balance enforcement unset, using default (true)")
+	} else {
+		enforceBalance, err = strconv.ParseBool(enforceBalanceStr)
+		if err != nil {
+			log.Fatalln("BILLING_ENFORCE_BALANCE must be true or false")
+		}
+	}
+
+	var allowCampaignPurge bool
+	allowCampaignPurgeStr := os.Getenv("ADMIN_ALLOW_CAMPAIGN_PURGE")
+	if allowCampaignPurgeStr == "" {
+		log.Println("Campaign purge permission unset, using default (false)")
+	} else {
+		allowCampaignPurge, err = strconv.ParseBool(allowCampaignPurgeStr)
+		if err != nil {
+			log.Fatalln("ADMIN_ALLOW_CAMPAIGN_PURGE must be true or false")
+		}
+	}
+
 	return &Config{
 		DatabaseURL:   dbURL,
 		ServerAddress: serverAddress,
@@ -145,5 +238,28 @@ func NewConfig() *Config {
 			BucketName:      minioBucketName,
 			PublicHost:      minioPublicHost,
 		},
+		Ads: AdsConfig{
+			ClicksLimitPolicy:     clicksLimitPolicy,
+			ImpressionsLimitSlack: impressionsLimitSlack,
+			RelevanceWeight:       relevanceWeight,
+			RevenueWeight:         revenueWeight,
+			DefaultMLScore:        int32(defaultMLScore),
+			MaxMLScore:            int32(maxMLScore),
+			EnforceBalance:        enforceBalance,
+		},
+		AllowCampaignPurge: allowCampaignPurge,
+	}
+}
+
+func parseWeight(envName string, defaultWeight float64) float64 {
+	weightStr := os.Getenv(envName)
+	if weightStr == "" {
+		log.Printf("%s unset, using default (%v)", envName, defaultWeight)
+		return defaultWeight
+	}
+	weight, err := strconv.ParseFloat(weightStr, 64)
+	if err != nil || weight < 0 {
+		log.Fatalf("%s must be a non-negative number", envName)
 	}
+	return weight
 }
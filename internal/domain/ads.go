@@ -12,3 +12,47 @@ type UserAd struct {
 	AdText       string    `json:"ad_text"`
 	AdvertiserID uuid.UUID `json:"advertiser_id"`
 }
+
+// AdCandidate is a campaign that can be shown to a client
+type AdCandidate struct {
+	Campaign         Campaign
+	Score            int32
+	ImpressionsCount int64
+	ExpectedRevenue  float64
+	// PacingLimit is the max number of impressions for the current day, 0 means no limit
+	PacingLimit int64
+}
+
+// AdCounters are the counters of the campaign used to check its limits
+type AdCounters struct {
+	Impressions            int64
+	BilledClicks           int64
+	ClientImpressions      int64
+	ClientDailyImpressions int64
+	DailyImpressions       int64
+	Spent                  float64
+	DailySpent             float64
+	// Balance of the campaign advertiser
+	Balance float64
+}
+
+// ImpressionsCounter is the number of impressions of the campaign to the client in the day
+type ImpressionsCounter struct {
+	CampaignID uuid.UUID
+	ClientID   uuid.UUID
+	Date       int32
+	Count      int64
+}
+
+// ClicksCounter is the number of billed clicks of the campaign
+type ClicksCounter struct {
+	CampaignID uuid.UUID
+	Count      int64
+}
+
+// SpentCounter is the money spent by the campaign in the day
+type SpentCounter struct {
+	CampaignID uuid.UUID
+	Date       int32
+	Amount     float64
+}
@@ -1,8 +1,42 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Advertiser struct {
-	ID   uuid.UUID `json:"advertiser_id"`
-	Name string    `json:"name"`
+	ID      uuid.UUID `json:"advertiser_id"`
+	Name    string    `json:"name"`
+	Balance float64   `json:"balance"`
+}
+
+type TopUpRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+// Ledger operations
+const (
+	LedgerTopUp      = "topup"
+	LedgerImpression = "impression"
+	LedgerClick      = "click"
+)
+
+// LedgerEntry is a change of the advertiser balance.
+// Positive amount is a top-up, negative amount is a charge
+type LedgerEntry struct {
+	ID           uuid.UUID  `json:"id"`
+	AdvertiserID uuid.UUID  `json:"advertiser_id"`
+	CampaignID   *uuid.UUID `json:"campaign_id,omitempty"`
+	Operation    string     `json:"operation"`
+	Amount       float64    `json:"amount"`
+	Date         int32      `json:"date"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// AdvertiserBalance is used to load balances to redis
+type AdvertiserBalance struct {
+	AdvertiserID uuid.UUID
+	Balance      float64
 }
@@ -0,0 +1,19 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Audience is a named targeting shared by campaigns of the advertiser.
+// If ClientIDs is not empty, only the listed clients match the audience
+type Audience struct {
+	ID           uuid.UUID   `json:"audience_id"`
+	AdvertiserID uuid.UUID   `json:"advertiser_id"`
+	Name         string      `json:"name"`
+	Targeting    Targeting   `json:"targeting"`
+	ClientIDs    []uuid.UUID `json:"client_ids,omitempty"`
+}
+
+type AudienceRequest struct {
+	Name      string      `json:"name"`
+	Targeting Targeting   `json:"targeting"`
+	ClientIDs []uuid.UUID `json:"client_ids,omitempty"`
+}
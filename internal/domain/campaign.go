@@ -2,50 +2,125 @@ package domain
 
 import "github.com/google/uuid"
 
+// Campaign statuses. Finished is not stored, campaign is finished after its end date
+const (
+	CampaignStatusDraft    = "draft"
+	CampaignStatusActive   = "active"
+	CampaignStatusPaused   = "paused"
+	CampaignStatusFinished = "finished"
+	CampaignStatusArchived = "archived"
+)
+
+// Campaign pacing modes
+const (
+	// PacingASAP shows the campaign as fast as traffic arrives
+	PacingASAP = "asap"
+	// PacingEven spreads impressions evenly across the campaign dates
+	PacingEven = "even"
+)
+
+// DefaultFrequencyCapTotal is used when the campaign total frequency cap is not set
+const DefaultFrequencyCapTotal int32 = 1
+
 type Campaign struct {
-	ID                uuid.UUID `json:"campaign_id"`
-	AdvertiserID      uuid.UUID `json:"advertiser_id"`
-	ImpressionsLimit  int64     `json:"impressions_limit"`
-	ClicksLimit       int64     `json:"clicks_limit"`
-	CostPerImpression float64   `json:"cost_per_impression"`
-	CostPerClick      float64   `json:"cost_per_click"`
-	AdTitle           string    `json:"ad_title"`
-	AdText            string    `json:"ad_text"`
-	StartDate         int32     `json:"start_date"`
-	EndDate           int32     `json:"end_date"`
-	Targeting         Targeting `json:"targeting"`
-	PicURL            *string   `json:"picture,omitempty"`
+	ID                uuid.UUID  `json:"campaign_id"`
+	AdvertiserID      uuid.UUID  `json:"advertiser_id"`
+	ImpressionsLimit  int64      `json:"impressions_limit"`
+	ClicksLimit       int64      `json:"clicks_limit"`
+	CostPerImpression float64    `json:"cost_per_impression"`
+	CostPerClick      float64    `json:"cost_per_click"`
+	AdTitle           string     `json:"ad_title"`
+	AdText            string     `json:"ad_text"`
+	StartDate         int32      `json:"start_date"`
+	EndDate           int32      `json:"end_date"`
+	FrequencyCapTotal int32      `json:"frequency_cap_total"`
+	FrequencyCapDaily int32      `json:"frequency_cap_daily"`
+	BudgetTotal       float64    `json:"budget_total"`
+	BudgetDaily       float64    `json:"budget_daily"`
+	Pacing            string     `json:"pacing"`
+	Status            string     `json:"status"`
+	Targeting         Targeting  `json:"targeting"`
+	AudienceID        *uuid.UUID `json:"audience_id,omitempty"`
+	PicURL            *string    `json:"picture,omitempty"`
+
+	// Audience is loaded only for ads selection
+	Audience *Audience `json:"-"`
+
+	RemainingBudgetTotal *float64 `json:"remaining_budget_total,omitempty"`
+	RemainingBudgetDaily *float64 `json:"remaining_budget_daily,omitempty"`
+}
+
+// CampaignVersion is the campaign snapshot saved on the day of its creation or update
+type CampaignVersion struct {
+	Version  int32    `json:"version"`
+	Date     int32    `json:"date"`
+	Campaign Campaign `json:"campaign"`
 }
 
 type CampaignRequest struct {
-	ImpressionsLimit  int64     `json:"impressions_limit"`
-	ClicksLimit       int64     `json:"clicks_limit"`
-	CostPerImpression float64   `json:"cost_per_impression"`
-	CostPerClick      float64   `json:"cost_per_click"`
-	AdTitle           string    `json:"ad_title"`
-	AdText            string    `json:"ad_text"`
-	StartDate         int32     `json:"start_date"`
-	EndDate           int32     `json:"end_date"`
-	Targeting         Targeting `json:"targeting"`
+	ImpressionsLimit  int64      `json:"impressions_limit"`
+	ClicksLimit       int64      `json:"clicks_limit"`
+	CostPerImpression float64    `json:"cost_per_impression"`
+	CostPerClick      float64    `json:"cost_per_click"`
+	AdTitle           string     `json:"ad_title"`
+	AdText            string     `json:"ad_text"`
+	StartDate         int32      `json:"start_date"`
+	EndDate           int32      `json:"end_date"`
+	FrequencyCapTotal *int32     `json:"frequency_cap_total,omitempty"`
+	FrequencyCapDaily *int32     `json:"frequency_cap_daily,omitempty"`
+	BudgetTotal       *float64   `json:"budget_total,omitempty"`
+	BudgetDaily       *float64   `json:"budget_daily,omitempty"`
+	Pacing            *string    `json:"pacing,omitempty"`
+	Status            *string    `json:"status,omitempty"`
+	Targeting         Targeting  `json:"targeting"`
+	AudienceID        *uuid.UUID `json:"audience_id,omitempty"`
 }
 
+// Interests matching modes
+const (
+	// InterestsModeAny matches clients with at least one of the interests
+	InterestsModeAny = "any"
+	// InterestsModeAll matches clients with all of the interests
+	InterestsModeAll = "all"
+)
+
+// Targeting restricts the campaign audience. Gender and Location are kept for
+// compatibility and are combined with Genders and Locations lists
 type Targeting struct {
-	Gender   *string `json:"gender,omitempty"`
-	AgeFrom  *int32  `json:"age_from,omitempty"`
-	AgeTo    *int32  `json:"age_to,omitempty"`
-	Location *string `json:"location,omitempty"`
+	Gender            *string        `json:"gender,omitempty"`
+	AgeFrom           *int32         `json:"age_from,omitempty"`
+	AgeTo             *int32         `json:"age_to,omitempty"`
+	Location          *string        `json:"location,omitempty"`
+	Genders           []string       `json:"genders,omitempty"`
+	Locations         []string       `json:"locations,omitempty"`
+	ExcludedLocations []string       `json:"excluded_locations,omitempty"`
+	Interests         []string       `json:"interests,omitempty"`
+	InterestsMode     *string        `json:"interests_mode,omitempty"`
+	Rules             *TargetingRule `json:"rules,omitempty"`
 }
 
 type CampaignUpdateRequest struct {
-	ImpressionsLimit  int64     `json:"impressions_limit"`
-	ClicksLimit       int64     `json:"clicks_limit"`
-	CostPerImpression float64   `json:"cost_per_impression"`
-	CostPerClick      float64   `json:"cost_per_click"`
-	AdTitle           string    `json:"ad_title"`
-	AdText            string    `json:"ad_text"`
-	StartDate         int32     `json:"start_date"`
-	EndDate           int32     `json:"end_date"`
-	Targeting         Targeting `json:"targeting"`
+	ImpressionsLimit  int64      `json:"impressions_limit"`
+	ClicksLimit       int64      `json:"clicks_limit"`
+	CostPerImpression float64    `json:"cost_per_impression"`
+	CostPerClick      float64    `json:"cost_per_click"`
+	AdTitle           string     `json:"ad_title"`
+	AdText            string     `json:"ad_text"`
+	StartDate         int32      `json:"start_date"`
+	EndDate           int32      `json:"end_date"`
+	FrequencyCapTotal *int32     `json:"frequency_cap_total,omitempty"`
+	FrequencyCapDaily *int32     `json:"frequency_cap_daily,omitempty"`
+	BudgetTotal       *float64   `json:"budget_total,omitempty"`
+	BudgetDaily       *float64   `json:"budget_daily,omitempty"`
+	Pacing            *string    `json:"pacing,omitempty"`
+	Targeting         Targeting  `json:"targeting"`
+	AudienceID        *uuid.UUID `json:"audience_id,omitempty"`
+}
+
+// CloneCampaignRequest sets the dates of the cloned campaign
+type CloneCampaignRequest struct {
+	StartDate int32 `json:"start_date"`
+	EndDate   int32 `json:"end_date"`
 }
 
 type GenerateAdTextRequest struct {
@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 type HttpError struct {
 	Status  int    `json:"-"`
@@ -16,7 +20,28 @@ var (
 	ErrUserNotFound            = errors.New("client not found")
 	ErrAdNotFound              = errors.New("ad not found")
 	ErrAdvertiserNotFound      = errors.New("advertiser not found")
+	ErrVersionNotFound         = errors.New("campaign version not found")
+	ErrAudienceNotFound        = errors.New("audience not found")
+	ErrAudienceInUse           = errors.New("audience is used by campaigns")
 
 	ErrNewDateLowerThanCurrent = errors.New("new date must be bigger than current")
 	ErrModerationNotPassed     = errors.New("moderation not passed")
+
+	ErrInsufficientBalance     = errors.New("advertiser balance doesn't cover the cost")
+	ErrPurgeNotAllowed         = errors.New("campaign purge is not allowed")
+	ErrInvalidStatusTransition = errors.New("invalid campaign status transition")
+	ErrCampaignNotEditable     = errors.New("campaign fields can't be changed in its current status")
 )
+
+// NotEditableFieldsError reports the fields which can't be changed in the campaign status
+type NotEditableFieldsError struct {
+	Fields []string
+}
+
+func (e *NotEditableFieldsError) Error() string {
+	return fmt.Sprintf("fields %s can't be changed after campaign start", strings.Join(e.Fields, ", "))
+}
+
+func (e *NotEditableFieldsError) Unwrap() error {
+	return ErrCampaignNotEditable
+}
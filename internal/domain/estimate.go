@@ -0,0 +1,25 @@
+package domain
+
+import "github.com/google/uuid"
+
+type EstimateRequest struct {
+	Targeting  Targeting  `json:"targeting"`
+	AudienceID *uuid.UUID `json:"audience_id,omitempty"`
+}
+
+// AudienceEstimate is the number of clients matching the targeting.
+// Scores are calculated only for matched clients with ML score for the advertiser
+type AudienceEstimate struct {
+	ClientsCount       int                `json:"clients_count"`
+	ScoredClientsCount int                `json:"scored_clients_count"`
+	Scores             *ScoreDistribution `json:"scores,omitempty"`
+}
+
+type ScoreDistribution struct {
+	Min    int32   `json:"min"`
+	P25    int32   `json:"p25"`
+	Median int32   `json:"median"`
+	P75    int32   `json:"p75"`
+	Max    int32   `json:"max"`
+	Avg    float64 `json:"avg"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Invoice formats
+const (
+	InvoiceFormatJSON = "json"
+	InvoiceFormatCSV  = "csv"
+)
+
+type Invoice struct {
+	AdvertiserID uuid.UUID     `json:"advertiser_id"`
+	From         int32         `json:"from"`
+	To           int32         `json:"to"`
+	Items        []InvoiceItem `json:"items"`
+	Total        float64       `json:"total"`
+}
+
+// InvoiceItem is the billed activity of one campaign in the invoice period
+type InvoiceItem struct {
+	CampaignID        uuid.UUID `json:"campaign_id"`
+	AdTitle           string    `json:"ad_title"`
+	ImpressionsCount  int64     `json:"impressions_count"`
+	ImpressionsAmount float64   `json:"impressions_amount"`
+	ClicksCount       int64     `json:"clicks_count"`
+	ClicksAmount      float64   `json:"clicks_amount"`
+	Total             float64   `json:"total"`
+}
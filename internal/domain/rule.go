@@ -0,0 +1,35 @@
+package domain
+
+// Targeting rule client attributes
+const (
+	RuleAttrAge       = "age"
+	RuleAttrGender    = "gender"
+	RuleAttrLocation  = "location"
+	RuleAttrInterests = "interests"
+)
+
+// Targeting rule operators. Age is compared with Value, other attributes with Values
+const (
+	RuleOpEq    = "eq"
+	RuleOpNe    = "ne"
+	RuleOpGte   = "gte"
+	RuleOpLte   = "lte"
+	RuleOpIn    = "in"
+	RuleOpNotIn = "not_in"
+	// RuleOpAny matches clients with at least one of the interests
+	RuleOpAny = "any"
+	// RuleOpAll matches clients with all of the interests
+	RuleOpAll = "all"
+)
+
+// TargetingRule is a node of the boolean expression over client attributes.
+// A node is either a group (And, Or or Not) or a condition (Attr, Op and Value or Values)
+type TargetingRule struct {
+	And    []TargetingRule `json:"and,omitempty"`
+	Or     []TargetingRule `json:"or,omitempty"`
+	Not    *TargetingRule  `json:"not,omitempty"`
+	Attr   string          `json:"attr,omitempty"`
+	Op     string          `json:"op,omitempty"`
+	Value  *int32          `json:"value,omitempty"`
+	Values []string        `json:"values,omitempty"`
+}
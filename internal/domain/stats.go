@@ -0,0 +1,21 @@
+package domain
+
+type Stats struct {
+	ImpressionsCount  int64   `json:"impressions_count"`
+	ClicksCount       int64   `json:"clicks_count"`
+	CappedClicksCount int64   `json:"capped_clicks_count"`
+	Conversion        float64 `json:"conversion"`
+	SpentImpressions  float64 `json:"spent_impressions"`
+	SpentClicks       float64 `json:"spent_clicks"`
+	SpentTotal        float64 `json:"spent_total"`
+}
+
+type DailyStats struct {
+	Date int32 `json:"date"`
+	Stats
+}
+
+type CampaignStats struct {
+	Stats
+	ImpressionsOvershoot int64 `json:"impressions_overshoot"`
+}
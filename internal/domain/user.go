@@ -3,9 +3,23 @@ package domain
 import "github.com/google/uuid"
 
 type User struct {
-	ID       uuid.UUID `json:"client_id"`
-	Login    string    `json:"login"`
-	Age      int32     `json:"age"`
-	Location string    `json:"location"`
-	Gender   string    `json:"gender"`
+	ID        uuid.UUID `json:"client_id"`
+	Login     string    `json:"login"`
+	Age       int32     `json:"age"`
+	Location  string    `json:"location"`
+	Gender    string    `json:"gender"`
+	Interests []string  `json:"interests,omitempty"`
+}
+
+// UserFilter narrows clients down in the database before the targeting is checked.
+// Empty fields match everyone
+type UserFilter struct {
+	AgeFrom           *int32
+	AgeTo             *int32
+	Genders           []string
+	Locations         []string
+	ExcludedLocations []string
+	InterestsAny      []string
+	InterestsAll      []string
+	ClientIDs         []uuid.UUID
 }
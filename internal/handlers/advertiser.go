@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -89,6 +90,113 @@ func (h *AdvertiserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(advertiser)
 }
 
+// TopUpBalance godoc
+//
+//	@Summary		Пополнение баланса рекламодателя
+//	@Description	Пополняет баланс рекламодателя и записывает пополнение в журнал операций
+//	@Tags			Advertisers
+//	@Accept			json
+//	@Param			advertiserId	path	string				true	"ID рекламодателя"
+//	@Param			TopUpRequest	body	domain.TopUpRequest	true	"TopUpRequest"
+//	@Produce		json
+//	@Success		200	{object}	domain.Advertiser
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/balance/topup [post]
+func (h *AdvertiserHandler) TopUpBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	var req domain.TopUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	advertiser, err := h.service.TopUpBalance(ctx, advertiserID, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBadRequest):
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "сумма пополнения должна быть больше 0")
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to top up balance: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(advertiser)
+}
+
+// GetLedger godoc
+//
+//	@Summary		Журнал операций рекламодателя
+//	@Description	Возвращает пополнения баланса и списания за показы и клики, начиная с последних
+//	@Tags			Advertisers
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			size			query		int		false	"Размер страницы"
+//	@Param			page			query		int		false	"Номер страницы"
+//	@Success		200				{object}	[]domain.LedgerEntry
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/ledger [get]
+func (h *AdvertiserHandler) GetLedger(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	var size, page int
+	sizeStr := r.URL.Query().Get("size")
+	if sizeStr == "" {
+		size = 10
+	} else {
+		sizeTmp, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный size")
+			return
+		}
+		size = sizeTmp
+	}
+
+	pageStr := r.URL.Query().Get("page")
+	if pageStr == "" {
+		page = 0
+	} else {
+		pageTmp, err := strconv.Atoi(pageStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный page")
+			return
+		}
+		page = pageTmp
+	}
+
+	entries, err := h.service.GetLedger(ctx, advertiserID, size, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to get ledger: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(entries)
+}
+
 // CreateUpdateMLScore godoc
 //
 //	@Summary		Добавление или обновление ML скора
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/app"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+type AudienceHandler struct {
+	service *app.AudienceService
+}
+
+func NewAudienceHandler(service *app.AudienceService) *AudienceHandler {
+	return &AudienceHandler{
+		service: service,
+	}
+}
+
+// CreateAudience godoc
+//
+//	@Summary		Создание аудитории
+//	@Description	Создает именованную аудиторию из правил таргетинга и (необязательно) списка ID клиентов. Аудиторию можно подключить к нескольким кампаниям рекламодателя
+//	@Tags			Audiences
+//	@Accept			json
+//	@Produce		json
+//	@Param			advertiserId	path		string					true	"ID рекламодателя"
+//	@Param			CreateAudience	body		domain.AudienceRequest	true	"Аудитория"
+//	@Success		201				{object}	domain.Audience
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/audiences [post]
+func (h *AudienceHandler) CreateAudience(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	var audienceRequest domain.AudienceRequest
+	if err := json.NewDecoder(r.Body).Decode(&audienceRequest); err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	audience, err := h.service.CreateAudience(ctx, advertiserID, audienceRequest)
+	if err != nil {
+		writeAudienceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(audience)
+}
+
+// GetAudiences godoc
+//
+//	@Summary		Получение аудиторий рекламодателя
+//	@Description	Возвращает аудитории рекламодателя
+//	@Tags			Audiences
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			size			query		int		false	"Размер страницы"
+//	@Param			page			query		int		false	"Номер страницы"
+//	@Success		200				{object}	[]domain.Audience
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/audiences [get]
+func (h *AudienceHandler) GetAudiences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	var size, page int
+	sizeStr := r.URL.Query().Get("size")
+	if sizeStr == "" {
+		size = 10
+	} else {
+		sizeTmp, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный size")
+			return
+		}
+		size = sizeTmp
+	}
+
+	pageStr := r.URL.Query().Get("page")
+	if pageStr == "" {
+		page = 0
+	} else {
+		pageTmp, err := strconv.Atoi(pageStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный page")
+			return
+		}
+		page = pageTmp
+	}
+
+	audiences, err := h.service.GetAudiences(ctx, advertiserID, size, page)
+	if err != nil {
+		writeAudienceError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(audiences)
+}
+
+// GetAudience godoc
+//
+//	@Summary		Получение аудитории
+//	@Description	Возвращает аудиторию рекламодателя по ее ID
+//	@Tags			Audiences
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			audienceId		path		string	true	"ID аудитории"
+//	@Success		200				{object}	domain.Audience
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/audiences/{audienceId} [get]
+func (h *AudienceHandler) GetAudience(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	audienceID, err := uuid.Parse(chi.URLParam(r, "audienceId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID аудитории")
+		return
+	}
+
+	audience, err := h.service.GetAudience(ctx, advertiserID, audienceID)
+	if err != nil {
+		writeAudienceError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(audience)
+}
+
+// UpdateAudience godoc
+//
+//	@Summary		Обновление аудитории
+//	@Description	Заменяет название, таргетинг и список клиентов аудитории. Изменения применяются ко всем кампаниям с этой аудиторией
+//	@Tags			Audiences
+//	@Accept			json
+//	@Produce		json
+//	@Param			advertiserId	path		string					true	"ID рекламодателя"
+//	@Param			audienceId		path		string					true	"ID аудитории"
+//	@Param			UpdateAudience	body		domain.AudienceRequest	true	"Аудитория"
+//	@Success		200				{object}	domain.Audience
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/audiences/{audienceId} [put]
+func (h *AudienceHandler) UpdateAudience(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	audienceID, err := uuid.Parse(chi.URLParam(r, "audienceId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID аудитории")
+		return
+	}
+
+	var audienceRequest domain.AudienceRequest
+	if err := json.NewDecoder(r.Body).Decode(&audienceRequest); err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	audience, err := h.service.UpdateAudience(ctx, advertiserID, audienceID, audienceRequest)
+	if err != nil {
+		writeAudienceError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(audience)
+}
+
+// DeleteAudience godoc
+//
+//	@Summary		Удаление аудитории
+//	@Description	Удаляет аудиторию и отключает ее от архивных и удаленных кампаний. Аудиторию, которую используют другие кампании, удалить нельзя
+//	@Tags			Audiences
+//	@Param			advertiserId	path	string	true	"ID рекламодателя"
+//	@Param			audienceId		path	string	true	"ID аудитории"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/audiences/{audienceId} [delete]
+func (h *AudienceHandler) DeleteAudience(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	audienceID, err := uuid.Parse(chi.URLParam(r, "audienceId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID аудитории")
+		return
+	}
+
+	if err := h.service.DeleteAudience(ctx, advertiserID, audienceID); err != nil {
+		writeAudienceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeAudienceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrBadRequest):
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+	case errors.Is(err, domain.ErrAdvertiserNotFound):
+		WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+	case errors.Is(err, domain.ErrAudienceNotFound):
+		WriteError(w, http.StatusNotFound, "Аудитория не найдена", "")
+	case errors.Is(err, domain.ErrAudienceInUse):
+		WriteError(w, http.StatusConflict, "Конфликт", "аудитория используется кампаниями, сначала отключите ее или архивируйте кампании")
+	default:
+		log.Printf("[INTERNAL ERROR] failed to process audience: %v", err)
+		WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+	}
+}
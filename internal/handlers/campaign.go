@@ -247,6 +247,7 @@ func (h *CampaignHandler) GetCampaignByID(w http.ResponseWriter, r *http.Request
 //	@Success		200	{object}	domain.Campaign
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/advertisers/{advertiserId}/campaigns/{campaignId} [put]
 func (h *CampaignHandler) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
@@ -273,31 +274,91 @@ func (h *CampaignHandler) UpdateCampaign(w http.ResponseWriter, r *http.Request)
 
 	newCampaign, err := h.service.UpdateCampaign(ctx, advertiserID, campaignID, campaignUpdate)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrAdvertiserNotFound):
-			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
-		case errors.Is(err, domain.ErrAdNotFound):
-			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
-		case errors.Is(err, domain.ErrModerationNotPassed):
-			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "модерация не пройдена")
-		case errors.Is(err, domain.ErrBadRequest):
-			WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
-		default:
-			log.Printf("[INTERNAL ERROR] failed to update campaign: %v", err)
-			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
-		}
+		writeUpdateCampaignError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(newCampaign)
+}
+
+// PatchCampaign godoc
+//
+//	@Summary		Частичное обновление кампании
+//	@Description	Применяет к рекламной кампании JSON Merge Patch (RFC 7396): переданные поля изменяются, отсутствующие остаются прежними, null сбрасывает необязательное поле
+//	@Tags			Campaigns
+//	@Accept			json
+//	@Produce		json
+//	@Param			advertiserId	path		string							true	"ID рекламодателя"
+//	@Param			campaignId		path		string							true	"ID рекламной кампании"
+//	@Param			PatchCampaign	body		domain.CampaignUpdateRequest	true	"Изменяемые поля кампании"
+//	@Success		200				{object}	domain.Campaign
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId} [patch]
+func (h *CampaignHandler) PatchCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	newCampaign, err := h.service.PatchCampaign(ctx, advertiserID, campaignID, patch)
+	if err != nil {
+		writeUpdateCampaignError(w, err)
 		return
 	}
 
 	json.NewEncoder(w).Encode(newCampaign)
 }
 
+func writeUpdateCampaignError(w http.ResponseWriter, err error) {
+	var fieldsErr *domain.NotEditableFieldsError
+	switch {
+	case errors.Is(err, domain.ErrAdvertiserNotFound):
+		WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+	case errors.Is(err, domain.ErrAdNotFound):
+		WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+	case errors.Is(err, domain.ErrModerationNotPassed):
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "модерация не пройдена")
+	case errors.Is(err, domain.ErrBadRequest):
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+	case errors.As(err, &fieldsErr):
+		WriteFieldsError(w, http.StatusConflict, "Конфликт", "поля не могут быть изменены после старта кампании", fieldsErr.Fields)
+	case errors.Is(err, domain.ErrCampaignNotEditable):
+		WriteError(w, http.StatusConflict, "Конфликт", "завершенная или архивная кампания не может быть изменена")
+	default:
+		log.Printf("[INTERNAL ERROR] failed to update campaign: %v", err)
+		WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+	}
+}
+
 // DeleteCampaign godoc
 //
 //	@Summary		Удаление рекламной кампании
-//	@Description	Удаляет рекламную кампанию по ее ID
+//	@Description	Удаляет рекламную кампанию по ее ID. Удаленная кампания не показывается и не возвращается в списке, но ее статистика и операции сохраняются. С hard=true кампания удаляется полностью вместе с показами и кликами, это административная операция, доступная только при ADMIN_ALLOW_CAMPAIGN_PURGE=true
 //	@Tags			Campaigns
+//	@Param			advertiserId	path	string	true	"ID рекламодателя"
+//	@Param			campaignId		path	string	true	"ID рекламной кампании"
+//	@Param			hard			query	bool	false	"Полное удаление"
 //	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
 //	@Failure		404	{object}	ErrorResponse
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/advertisers/{advertiserId}/campaigns/{campaignId} [delete]
@@ -316,13 +377,24 @@ func (h *CampaignHandler) DeleteCampaign(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.service.DeleteCampaign(ctx, advertiserID, campaignID)
+	hard := false
+	if hardStr := r.URL.Query().Get("hard"); hardStr != "" {
+		hard, err = strconv.ParseBool(hardStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный hard")
+			return
+		}
+	}
+
+	err = h.service.DeleteCampaign(ctx, advertiserID, campaignID, hard)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrAdvertiserNotFound):
 			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
 		case errors.Is(err, domain.ErrAdNotFound):
 			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+		case errors.Is(err, domain.ErrPurgeNotAllowed):
+			WriteError(w, http.StatusForbidden, "Доступ запрещен", "полное удаление кампаний выключено")
 		default:
 			log.Printf("[INTERNAL ERROR] failed to delete campaign: %v", err)
 			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// CloneCampaign godoc
+//
+//	@Summary		Копирование рекламной кампании
+//	@Description	Создает черновик с креативом, таргетингом, лимитами и картинкой рекламной кампании и новыми датами. Копия проходит те же проверки и модерацию, что и новая кампания
+//	@Tags			Campaigns
+//	@Accept			json
+//	@Produce		json
+//	@Param			advertiserId	path		string						true	"ID рекламодателя"
+//	@Param			campaignId		path		string						true	"ID копируемой рекламной кампании"
+//	@Param			CloneCampaign	body		domain.CloneCampaignRequest	true	"Даты новой кампании"
+//	@Success		201				{object}	domain.Campaign
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId}/clone [post]
+func (h *CampaignHandler) CloneCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	var cloneRequest domain.CloneCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&cloneRequest); err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	campaign, err := h.service.CloneCampaign(ctx, advertiserID, campaignID, cloneRequest)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBadRequest), errors.Is(err, domain.ErrModerationNotPassed):
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		case errors.Is(err, domain.ErrAdNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to clone campaign: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(campaign)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// PauseCampaign godoc
+//
+//	@Summary		Приостановка рекламной кампании
+//	@Description	Переводит активную рекламную кампанию в статус paused, она перестает показываться
+//	@Tags			Campaigns
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			campaignId		path		string	true	"ID рекламной кампании"
+//	@Success		200				{object}	domain.Campaign
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId}/pause [post]
+func (h *CampaignHandler) PauseCampaign(w http.ResponseWriter, r *http.Request) {
+	h.changeCampaignStatus(w, r, h.service.PauseCampaign)
+}
+
+// ResumeCampaign godoc
+//
+//	@Summary		Возобновление рекламной кампании
+//	@Description	Переводит приостановленную кампанию или черновик в статус active
+//	@Tags			Campaigns
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			campaignId		path		string	true	"ID рекламной кампании"
+//	@Success		200				{object}	domain.Campaign
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId}/resume [post]
+func (h *CampaignHandler) ResumeCampaign(w http.ResponseWriter, r *http.Request) {
+	h.changeCampaignStatus(w, r, h.service.ResumeCampaign)
+}
+
+// ArchiveCampaign godoc
+//
+//	@Summary		Архивация рекламной кампании
+//	@Description	Переводит рекламную кампанию в статус archived, после этого её нельзя изменить или возобновить
+//	@Tags			Campaigns
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			campaignId		path		string	true	"ID рекламной кампании"
+//	@Success		200				{object}	domain.Campaign
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId}/archive [post]
+func (h *CampaignHandler) ArchiveCampaign(w http.ResponseWriter, r *http.Request) {
+	h.changeCampaignStatus(w, r, h.service.ArchiveCampaign)
+}
+
+func (h *CampaignHandler) changeCampaignStatus(w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, advertiserID, campaignID uuid.UUID) (*domain.Campaign, error)) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	campaign, err := change(ctx, advertiserID, campaignID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		case errors.Is(err, domain.ErrAdNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			WriteError(w, http.StatusConflict, "Конфликт", "недопустимая смена статуса рекламной кампании")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to change campaign status: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(campaign)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// GetCampaignVersions godoc
+//
+//	@Summary		История изменений рекламной кампании
+//	@Description	Возвращает версии рекламной кампании, начиная с последней. Версия сохраняется при каждом создании и обновлении кампании
+//	@Tags			Campaigns
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			campaignId		path		string	true	"ID рекламной кампании"
+//	@Param			size			query		int		false	"Размер страницы"
+//	@Param			page			query		int		false	"Номер страницы"
+//	@Success		200				{object}	[]domain.CampaignVersion
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId}/versions [get]
+func (h *CampaignHandler) GetCampaignVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	var size, page int
+	sizeStr := r.URL.Query().Get("size")
+	if sizeStr == "" {
+		size = 10
+	} else {
+		sizeTmp, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный size")
+			return
+		}
+		size = sizeTmp
+	}
+
+	pageStr := r.URL.Query().Get("page")
+	if pageStr == "" {
+		page = 0
+	} else {
+		pageTmp, err := strconv.Atoi(pageStr)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный page")
+			return
+		}
+		page = pageTmp
+	}
+
+	versions, err := h.service.GetCampaignVersions(ctx, advertiserID, campaignID, size, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		case errors.Is(err, domain.ErrAdNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to get campaign versions: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(versions)
+}
+
+// RestoreCampaignVersion godoc
+//
+//	@Summary		Откат рекламной кампании к версии
+//	@Description	Возвращает заголовок, текст и таргетинг рекламной кампании из указанной версии. Восстановленный текст проходит модерацию, а результат сохраняется как новая версия
+//	@Tags			Campaigns
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			campaignId		path		string	true	"ID рекламной кампании"
+//	@Param			version			path		int		true	"Номер версии"
+//	@Success		200				{object}	domain.Campaign
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/{campaignId}/versions/{version}/restore [post]
+func (h *CampaignHandler) RestoreCampaignVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	version, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 32)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный номер версии")
+		return
+	}
+
+	campaign, err := h.service.RestoreCampaignVersion(ctx, advertiserID, campaignID, int32(version))
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionNotFound) {
+			WriteError(w, http.StatusNotFound, "Версия рекламной кампании не найдена", "")
+			return
+		}
+		writeUpdateCampaignError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(campaign)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/app"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+type EstimateHandler struct {
+	service *app.EstimateService
+}
+
+func NewEstimateHandler(service *app.EstimateService) *EstimateHandler {
+	return &EstimateHandler{
+		service: service,
+	}
+}
+
+// EstimateAudience godoc
+//
+//	@Summary		Оценка охвата таргетинга
+//	@Description	Возвращает количество клиентов, подходящих таргетингу (и аудитории, если указана), и распределение ML скоров рекламодателя среди них. Клиенты проверяются по тем же правилам, что и при выборе рекламы
+//	@Tags			Campaigns
+//	@Accept			json
+//	@Produce		json
+//	@Param			advertiserId	path		string					true	"ID рекламодателя"
+//	@Param			Estimate		body		domain.EstimateRequest	true	"Таргетинг"
+//	@Success		200				{object}	domain.AudienceEstimate
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/campaigns/estimate [post]
+func (h *EstimateHandler) EstimateAudience(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	var estimateRequest domain.EstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&estimateRequest); err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	estimate, err := h.service.EstimateAudience(ctx, advertiserID, estimateRequest)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBadRequest):
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to estimate audience: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(estimate)
+}
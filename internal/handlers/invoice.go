@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/app"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+type InvoiceHandler struct {
+	service *app.InvoiceService
+}
+
+func NewInvoiceHandler(service *app.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		service: service,
+	}
+}
+
+// GetInvoice godoc
+//
+//	@Summary		Получение счета рекламодателя за период
+//	@Description	Возвращает счет с затратами на показы и клики по каждой кампании рекламодателя за дни from..to включительно
+//	@Tags			Advertisers
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			advertiserId	path		string	true	"ID рекламодателя"
+//	@Param			from			query		int		false	"Первый день периода (по умолчанию 0)"
+//	@Param			to				query		int		false	"Последний день периода (по умолчанию текущий день)"
+//	@Param			format			query		string	false	"Формат счета: json или csv (по умолчанию json)"
+//	@Success		200				{object}	domain.Invoice
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/advertisers/{advertiserId}/invoices [get]
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	var from int32
+	fromStr := r.URL.Query().Get("from")
+	if fromStr != "" {
+		fromTmp, err := strconv.ParseInt(fromStr, 10, 32)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный from")
+			return
+		}
+		from = int32(fromTmp)
+	}
+
+	var to *int32
+	toStr := r.URL.Query().Get("to")
+	if toStr != "" {
+		toTmp, err := strconv.ParseInt(toStr, 10, 32)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный to")
+			return
+		}
+		toInt32 := int32(toTmp)
+		to = &toInt32
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = domain.InvoiceFormatJSON
+	}
+	if format != domain.InvoiceFormatJSON && format != domain.InvoiceFormatCSV {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "format должен быть json или csv")
+		return
+	}
+
+	invoice, err := h.service.GetInvoice(ctx, advertiserID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBadRequest):
+			WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный период")
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to get invoice: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	if format == domain.InvoiceFormatCSV {
+		filename := fmt.Sprintf("invoice_%s_%d_%d.csv", advertiserID, invoice.From, invoice.To)
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if err := writeInvoiceCSV(w, invoice); err != nil {
+			log.Printf("[INTERNAL ERROR] failed to write invoice csv: %v", err)
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(invoice)
+}
+
+// writeInvoiceCSV writes one line per campaign and the total line at the end
+func writeInvoiceCSV(w http.ResponseWriter, invoice *domain.Invoice) error {
+	writer := csv.NewWriter(w)
+	records := [][]string{{
+		"campaign_id", "ad_title",
+		"impressions_count", "impressions_amount",
+		"clicks_count", "clicks_amount",
+		"total",
+	}}
+	for _, item := range invoice.Items {
+		records = append(records, []string{
+			item.CampaignID.String(),
+			item.AdTitle,
+			strconv.FormatInt(item.ImpressionsCount, 10),
+			strconv.FormatFloat(item.ImpressionsAmount, 'f', 2, 64),
+			strconv.FormatInt(item.ClicksCount, 10),
+			strconv.FormatFloat(item.ClicksAmount, 'f', 2, 64),
+			strconv.FormatFloat(item.Total, 'f', 2, 64),
+		})
+	}
+	records = append(records, []string{"", "total", "", "", "", "", strconv.FormatFloat(invoice.Total, 'f', 2, 64)})
+	return writer.WriteAll(records)
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
+	Error   string   `json:"error"`
+	Details string   `json:"details,omitempty"`
+	Fields  []string `json:"fields,omitempty"`
 }
 
 func WriteError(w http.ResponseWriter, status int, msg, details string) {
@@ -18,3 +19,14 @@ func WriteError(w http.ResponseWriter, status int, msg, details string) {
 		Details: details,
 	})
 }
+
+// WriteFieldsError writes the error with the list of request fields which caused it
+func WriteFieldsError(w http.ResponseWriter, status int, msg, details string, fields []string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error:   msg,
+		Details: details,
+		Fields:  fields,
+	})
+}
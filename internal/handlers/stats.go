@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/app"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+type StatsHandler struct {
+	service *app.StatsService
+}
+
+func NewStatsHandler(service *app.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// GetCampaignStats godoc
+//
+//	@Summary		Получение статистики по рекламной кампании
+//	@Description	Возвращает количество показов и кликов, конверсию, затраты рекламной кампании и превышение лимита показов
+//	@Tags			Statistics
+//	@Produce		json
+//	@Param			campaignId	path		string	true	"UUID рекламной кампании"
+//	@Success		200			{object}	domain.CampaignStats
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/stats/campaigns/{campaignId} [get]
+func (h *StatsHandler) GetCampaignStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	stats, err := h.service.GetCampaignStats(ctx, campaignID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to get campaign stats: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(stats)
+}
+
+// GetCampaignDailyStats godoc
+//
+//	@Summary		Получение ежедневной статистики по рекламной кампании
+//	@Description	Возвращает статистику рекламной кампании за каждый день с её старта до текущего дня
+//	@Tags			Statistics
+//	@Produce		json
+//	@Param			campaignId	path		string	true	"UUID рекламной кампании"
+//	@Success		200			{object}	[]domain.DailyStats
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/stats/campaigns/{campaignId}/daily [get]
+func (h *StatsHandler) GetCampaignDailyStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	campaignID, err := uuid.Parse(chi.URLParam(r, "campaignId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламной кампании")
+		return
+	}
+
+	dailyStats, err := h.service.GetCampaignDailyStats(ctx, campaignID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламная кампания не найдена", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to get campaign daily stats: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(dailyStats)
+}
+
+// GetAdvertiserDailyStats godoc
+//
+//	@Summary		Получение ежедневной статистики по кампаниям рекламодателя
+//	@Description	Возвращает суммарную статистику всех рекламных кампаний рекламодателя за каждый день
+//	@Tags			Statistics
+//	@Produce		json
+//	@Param			advertiserId	path		string	true	"UUID рекламодателя"
+//	@Success		200				{object}	[]domain.DailyStats
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/stats/advertisers/{advertiserId}/campaigns/daily [get]
+func (h *StatsHandler) GetAdvertiserDailyStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	advertiserID, err := uuid.Parse(chi.URLParam(r, "advertiserId"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Некорректный запрос", "невалидный ID рекламодателя")
+		return
+	}
+
+	dailyStats, err := h.service.GetAdvertiserDailyStats(ctx, advertiserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdvertiserNotFound):
+			WriteError(w, http.StatusNotFound, "Рекламодатель не найден", "")
+		default:
+			log.Printf("[INTERNAL ERROR] failed to get advertiser daily stats: %v", err)
+			WriteError(w, http.StatusInternalServerError, domain.ErrInternalServerError.Error(), "")
+		}
+		return
+	}
+
+	json.NewEncoder(w).Encode(dailyStats)
+}
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE impressions ADD COLUMN IF NOT EXISTS date INT NOT NULL DEFAULT 0;
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS date INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS impressions_campaign_id_date_idx ON impressions (campaign_id, date);
+CREATE INDEX IF NOT EXISTS clicks_campaign_id_date_idx ON clicks (campaign_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS clicks_campaign_id_date_idx;
+DROP INDEX IF EXISTS impressions_campaign_id_date_idx;
+
+ALTER TABLE clicks DROP COLUMN IF EXISTS date;
+ALTER TABLE impressions DROP COLUMN IF EXISTS date;
+-- +goose StatementEnd
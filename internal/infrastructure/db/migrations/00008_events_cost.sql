@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE impressions ADD COLUMN IF NOT EXISTS cost DECIMAL(10,2) NOT NULL DEFAULT 0.00;
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS cost DECIMAL(10,2) NOT NULL DEFAULT 0.00;
+
+-- Existing events are billed with the current campaign prices
+UPDATE impressions SET cost = campaigns.cost_per_impression
+FROM campaigns
+WHERE campaigns.id = impressions.campaign_id;
+
+UPDATE clicks SET cost = campaigns.cost_per_click
+FROM campaigns
+WHERE campaigns.id = clicks.campaign_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN IF EXISTS cost;
+ALTER TABLE impressions DROP COLUMN IF EXISTS cost;
+-- +goose StatementEnd
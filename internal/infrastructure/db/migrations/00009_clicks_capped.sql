@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS capped BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE clicks DROP COLUMN IF EXISTS capped;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 0 means that there is no cap
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS frequency_cap_total INT NOT NULL DEFAULT 1 CHECK (frequency_cap_total >= 0);
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS frequency_cap_daily INT NOT NULL DEFAULT 0 CHECK (frequency_cap_daily >= 0);
+
+CREATE INDEX IF NOT EXISTS impressions_campaign_id_client_id_idx ON impressions (campaign_id, client_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS impressions_campaign_id_client_id_idx;
+
+ALTER TABLE campaigns DROP COLUMN IF EXISTS frequency_cap_daily;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS frequency_cap_total;
+-- +goose StatementEnd
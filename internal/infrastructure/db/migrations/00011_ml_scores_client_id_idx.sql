@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS ml_scores_client_id_advertiser_id_idx ON ml_scores (client_id, advertiser_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ml_scores_client_id_advertiser_id_idx;
+-- +goose StatementEnd
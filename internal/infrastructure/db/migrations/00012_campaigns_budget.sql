@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 0 means that there is no budget cap
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS budget_total DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (budget_total >= 0);
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS budget_daily DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (budget_daily >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns DROP COLUMN IF EXISTS budget_daily;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS budget_total;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS pacing VARCHAR(10) NOT NULL DEFAULT 'asap' CHECK (pacing IN ('asap', 'even'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns DROP COLUMN IF EXISTS pacing;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE advertisers ADD COLUMN IF NOT EXISTS balance DECIMAL(12,2) NOT NULL DEFAULT 0;
+
+-- Positive amount is a top-up, negative amount is a charge for an impression or a click
+CREATE TABLE IF NOT EXISTS ledger (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    advertiser_id UUID NOT NULL REFERENCES advertisers(id) ON DELETE CASCADE,
+    campaign_id UUID REFERENCES campaigns(id) ON DELETE SET NULL,
+    operation VARCHAR(20) NOT NULL CHECK (operation IN ('topup', 'impression', 'click')),
+    amount DECIMAL(12,2) NOT NULL,
+    date INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS ledger_advertiser_id_idx ON ledger (advertiser_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ledger;
+
+ALTER TABLE advertisers DROP COLUMN IF EXISTS balance;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- finished status is not stored, it is derived from end_date and the current date
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('draft', 'active', 'paused', 'archived'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Snapshot of the campaign after each create and update
+CREATE TABLE IF NOT EXISTS campaign_versions (
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    date INT NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (campaign_id, version)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS campaign_versions;
+-- +goose StatementEnd
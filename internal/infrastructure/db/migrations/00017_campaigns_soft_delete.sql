@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted campaigns are kept to preserve their statistics and billing history
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Lists extend single gender and location, empty list means no restriction
+ALTER TABLE campaigns_targeting ADD COLUMN IF NOT EXISTS genders TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE campaigns_targeting ADD COLUMN IF NOT EXISTS locations TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE campaigns_targeting ADD COLUMN IF NOT EXISTS excluded_locations TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns_targeting DROP COLUMN IF EXISTS excluded_locations;
+ALTER TABLE campaigns_targeting DROP COLUMN IF EXISTS locations;
+ALTER TABLE campaigns_targeting DROP COLUMN IF EXISTS genders;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS interests TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE campaigns_targeting ADD COLUMN IF NOT EXISTS interests TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE campaigns_targeting ADD COLUMN IF NOT EXISTS interests_mode VARCHAR(3) CHECK (interests_mode IN ('any', 'all'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns_targeting DROP COLUMN IF EXISTS interests_mode;
+ALTER TABLE campaigns_targeting DROP COLUMN IF EXISTS interests;
+
+ALTER TABLE users DROP COLUMN IF EXISTS interests;
+-- +goose StatementEnd
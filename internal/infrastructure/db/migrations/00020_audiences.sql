@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Named targeting shared by campaigns of the advertiser. Clients list is
+-- optional, clients don't have to exist when the list is uploaded
+CREATE TABLE IF NOT EXISTS audiences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    advertiser_id UUID NOT NULL REFERENCES advertisers(id) ON DELETE CASCADE,
+    name VARCHAR NOT NULL,
+    targeting JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS audience_clients (
+    audience_id UUID NOT NULL REFERENCES audiences(id) ON DELETE CASCADE,
+    client_id UUID NOT NULL,
+    PRIMARY KEY (audience_id, client_id)
+);
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS audience_id UUID REFERENCES audiences(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns DROP COLUMN IF EXISTS audience_id;
+
+DROP TABLE IF EXISTS audience_clients;
+
+DROP TABLE IF EXISTS audiences;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Boolean expression over client attributes, combined with other targeting fields by AND
+ALTER TABLE campaigns_targeting ADD COLUMN IF NOT EXISTS rules JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE campaigns_targeting DROP COLUMN IF EXISTS rules;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS users_location_idx ON users (location);
+CREATE INDEX IF NOT EXISTS users_age_idx ON users (age);
+CREATE INDEX IF NOT EXISTS users_interests_idx ON users USING GIN (interests);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_interests_idx;
+DROP INDEX IF EXISTS users_age_idx;
+DROP INDEX IF EXISTS users_location_idx;
+-- +goose StatementEnd
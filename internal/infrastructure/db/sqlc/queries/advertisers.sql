@@ -27,6 +27,14 @@ WHERE
 client_id = @client_id::uuid AND
 advertiser_id = @advertiser_id::uuid;
 
+-- name: GetMLScoresByClientID :many
+SELECT * FROM ml_scores
+WHERE client_id = @client_id::uuid;
+
+-- name: GetMLScoresByAdvertiserID :many
+SELECT * FROM ml_scores
+WHERE advertiser_id = @advertiser_id::uuid;
+
 -- name: UpdateMLScore :exec
 UPDATE ml_scores
 SET score = @score::int
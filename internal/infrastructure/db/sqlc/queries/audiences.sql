@@ -0,0 +1,49 @@
+-- name: CreateAudience :one
+INSERT INTO audiences (advertiser_id, name, targeting)
+VALUES (@advertiser_id::uuid, @name::varchar, @targeting::jsonb)
+RETURNING *;
+
+-- name: UpdateAudience :one
+UPDATE audiences
+SET name = @name::varchar, targeting = @targeting::jsonb
+WHERE id = @id::uuid
+RETURNING *;
+
+-- name: GetAudienceByID :one
+SELECT * FROM audiences
+WHERE id = @id::uuid;
+
+-- name: GetAudiencesByAdvertiserID :many
+SELECT * FROM audiences
+WHERE advertiser_id = @advertiser_id::uuid
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2;
+
+-- name: GetAudiencesByIDs :many
+SELECT * FROM audiences
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: DeleteUnusedAudience :execrows
+-- Audience used by campaigns that are not archived or deleted is kept
+DELETE FROM audiences
+WHERE id = @id::uuid AND NOT EXISTS (
+    SELECT 1 FROM campaigns
+    WHERE
+        campaigns.audience_id = @id::uuid AND
+        campaigns.status <> 'archived' AND
+        campaigns.deleted_at IS NULL
+);
+
+-- name: AddAudienceClients :exec
+INSERT INTO audience_clients (audience_id, client_id)
+SELECT @audience_id::uuid, unnest(@client_ids::uuid[])
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteAudienceClients :exec
+DELETE FROM audience_clients
+WHERE audience_id = @audience_id::uuid;
+
+-- name: GetAudienceClients :many
+SELECT audience_id, client_id FROM audience_clients
+WHERE audience_id = ANY(@audience_ids::uuid[])
+ORDER BY audience_id, client_id;
@@ -0,0 +1,19 @@
+-- name: CreateCampaignVersion :one
+INSERT INTO campaign_versions (campaign_id, version, date, snapshot)
+VALUES (
+    @campaign_id::uuid,
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM campaign_versions WHERE campaign_id = @campaign_id::uuid),
+    @date::int,
+    @snapshot::jsonb
+)
+RETURNING *;
+
+-- name: GetCampaignVersions :many
+SELECT * FROM campaign_versions
+WHERE campaign_id = @campaign_id::uuid
+ORDER BY version DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetCampaignVersion :one
+SELECT * FROM campaign_versions
+WHERE campaign_id = @campaign_id::uuid AND version = @version::int;
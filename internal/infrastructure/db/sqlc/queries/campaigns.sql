@@ -4,13 +4,21 @@ INSERT INTO campaigns (
     impressions_limit, clicks_limit,
     cost_per_impression, cost_per_click,
     ad_title, ad_text,
-    start_date, end_date
+    start_date, end_date,
+    frequency_cap_total, frequency_cap_daily,
+    budget_total, budget_daily,
+    pacing, status,
+    audience_id
 ) VALUES (
     @advertiser_id::uuid,
     @impressions_limit::bigint, @clicks_limit::bigint,
     @cost_per_impression::decimal(10,2), @cost_per_click::decimal(10,2),
     @ad_title::varchar, @ad_text::varchar,
-    @start_date::int, @end_date::int
+    @start_date::int, @end_date::int,
+    COALESCE(sqlc.narg(frequency_cap_total)::int, 1), COALESCE(sqlc.narg(frequency_cap_daily)::int, 0),
+    COALESCE(sqlc.narg(budget_total)::decimal(10,2), 0), COALESCE(sqlc.narg(budget_daily)::decimal(10,2), 0),
+    COALESCE(sqlc.narg(pacing)::varchar, 'asap'), COALESCE(sqlc.narg(status)::varchar, 'active'),
+    sqlc.narg(audience_id)::uuid
 )
 RETURNING *;
 
@@ -19,12 +27,19 @@ INSERT INTO campaigns_targeting (
     campaign_id,
     gender,
     age_from, age_to,
-    location
+    location,
+    genders, locations, excluded_locations,
+    interests, interests_mode,
+    rules
 ) VALUES (
     @campaign_id::uuid,
     COALESCE(sqlc.narg(gender)::varchar, NULL),
     COALESCE(sqlc.narg(age_from)::int, NULL), COALESCE(sqlc.narg(age_to)::int, NULL),
-    COALESCE(sqlc.narg(location)::varchar, NULL)
+    COALESCE(sqlc.narg(location)::varchar, NULL),
+    COALESCE(sqlc.narg(genders)::text[], '{}'), COALESCE(sqlc.narg(locations)::text[], '{}'),
+    COALESCE(sqlc.narg(excluded_locations)::text[], '{}'),
+    COALESCE(sqlc.narg(interests)::text[], '{}'), COALESCE(sqlc.narg(interests_mode)::varchar, NULL),
+    sqlc.narg(rules)::jsonb
 )
 RETURNING *;
 
@@ -40,12 +55,16 @@ SELECT pic_id FROM campaigns
 WHERE id = @campaign_id::uuid; 
 
 -- name: GetCampaignsWithTargetingByAdvertiserID :many
-SELECT * FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
-WHERE advertiser_id = @advertiser_id::uuid
+SELECT sqlc.embed(campaigns), sqlc.embed(campaigns_targeting) FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+WHERE advertiser_id = @advertiser_id::uuid AND campaigns.deleted_at IS NULL
 LIMIT $1 OFFSET $2;
 
 -- name: GetCampaignWithTargetingByID :one
-SELECT * FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+SELECT sqlc.embed(campaigns), sqlc.embed(campaigns_targeting) FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+WHERE campaigns.id = @campaign_id::uuid AND campaigns.deleted_at IS NULL;
+
+-- name: GetCampaignWithTargetingByIDIncludingDeleted :one
+SELECT sqlc.embed(campaigns), sqlc.embed(campaigns_targeting) FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
 WHERE campaigns.id = @campaign_id::uuid;
 
 -- name: UpdateCampaign :one
@@ -54,7 +73,13 @@ SET
     impressions_limit = @impressions_limit::bigint, clicks_limit = @clicks_limit::bigint,
     cost_per_impression = @cost_per_impression::decimal(10,2), cost_per_click = @cost_per_click::decimal(10,2),
     ad_title = @ad_title::varchar, ad_text = @ad_text::varchar,
-    start_date = @start_date::int, end_date = @end_date::int
+    start_date = @start_date::int, end_date = @end_date::int,
+    frequency_cap_total = COALESCE(sqlc.narg(frequency_cap_total)::int, frequency_cap_total),
+    frequency_cap_daily = COALESCE(sqlc.narg(frequency_cap_daily)::int, frequency_cap_daily),
+    budget_total = COALESCE(sqlc.narg(budget_total)::decimal(10,2), budget_total),
+    budget_daily = COALESCE(sqlc.narg(budget_daily)::decimal(10,2), budget_daily),
+    pacing = COALESCE(sqlc.narg(pacing)::varchar, pacing),
+    audience_id = sqlc.narg(audience_id)::uuid
 WHERE
     id = @campaign_id::uuid
 RETURNING *;
@@ -64,40 +89,40 @@ UPDATE campaigns_targeting
 SET
     gender = COALESCE(sqlc.narg(gender)::varchar, NULL),
     age_from = COALESCE(sqlc.narg(age_from)::int, NULL), age_to = COALESCE(sqlc.narg(age_to)::int, NULL),
-    location = COALESCE(sqlc.narg(location)::varchar, NULL)
+    location = COALESCE(sqlc.narg(location)::varchar, NULL),
+    genders = COALESCE(sqlc.narg(genders)::text[], '{}'), locations = COALESCE(sqlc.narg(locations)::text[], '{}'),
+    excluded_locations = COALESCE(sqlc.narg(excluded_locations)::text[], '{}'),
+    interests = COALESCE(sqlc.narg(interests)::text[], '{}'), interests_mode = COALESCE(sqlc.narg(interests_mode)::varchar, NULL),
+    rules = sqlc.narg(rules)::jsonb
 WHERE
     campaign_id = @campaign_id::uuid
 RETURNING *;
 
+-- name: SetCampaignStatus :one
+UPDATE campaigns
+SET status = @status::varchar
+WHERE id = @campaign_id::uuid
+RETURNING *;
+
+-- name: SoftDeleteCampaignByID :exec
+UPDATE campaigns
+SET deleted_at = NOW()
+WHERE id = @campaign_id::uuid AND deleted_at IS NULL;
+
 -- name: DeleteCampaignByID :exec
 DELETE FROM campaigns
 WHERE id = @campaign_id::uuid;
 
--- name: GetRelativeAd :one
-SELECT * FROM campaigns 
+-- name: GetActiveCampaignsWithTargeting :many
+SELECT sqlc.embed(campaigns), sqlc.embed(campaigns_targeting) FROM campaigns
 JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
-JOIN ml_scores ON campaigns.advertiser_id = ml_scores.advertiser_id
-WHERE 
-    NOT EXISTS (
-        SELECT 1 FROM impressions 
-        WHERE impressions.campaign_id = campaigns.id 
-          AND impressions.client_id = @client_id::uuid
-    ) AND
-    (
-        SELECT COUNT(*) FROM impressions 
-        WHERE impressions.campaign_id = campaigns.id
-    ) < campaigns.impressions_limit
-    AND
-    (gender = @gender::varchar OR gender = 'ALL' OR gender IS NULL) AND
-    (
-        ((age_from IS NULL AND age_to >= @age::int) OR 
-         (age_to IS NULL AND age_from <= @age::int) OR 
-         (age_from IS NULL AND age_to IS NULL)) OR
-        (age_from <= @age::int AND age_to >= @age::int)
-    ) AND
-    (location IS NULL OR location = @location::varchar) AND
-    ml_scores.client_id = @client_id::uuid AND
-    campaigns.start_date <= @cur_date::int AND 
-    campaigns.end_date >= @cur_date::int
-ORDER BY score DESC, cost_per_impression DESC
-LIMIT 1;
+WHERE
+    campaigns.deleted_at IS NULL AND
+    campaigns.status = 'active' AND
+    campaigns.start_date <= @cur_date::int AND
+    campaigns.end_date >= @cur_date::int;
+
+-- name: GetUnfinishedCampaignIDs :many
+-- Campaigns that still can be shown, their counters are kept in redis
+SELECT id FROM campaigns
+WHERE deleted_at IS NULL AND end_date >= @cur_date::int;
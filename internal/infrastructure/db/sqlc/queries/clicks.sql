@@ -1,13 +1,36 @@
 -- name: CreateClick :one
+-- Clicks over the campaign clicks_limit are recorded as capped and are not billed
 INSERT INTO clicks (
-    campaign_id, client_id
-) VALUES (
-    @campaign_id::uuid, @client_id::uuid
+    campaign_id, client_id, date, cost, capped
 )
+SELECT
+    campaigns.id, @client_id::uuid, @date::int,
+    CASE
+        WHEN billed_clicks.count >= campaigns.clicks_limit THEN 0.00
+        ELSE campaigns.cost_per_click
+    END,
+    billed_clicks.count >= campaigns.clicks_limit
+FROM campaigns
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS count FROM clicks
+    WHERE clicks.campaign_id = campaigns.id AND NOT clicks.capped
+) AS billed_clicks
+WHERE campaigns.id = @campaign_id::uuid
 RETURNING *;
 
+-- name: SetClickUnbilled :exec
+-- Click the advertiser can't pay for is recorded as capped and is not billed
+UPDATE clicks
+SET cost = 0.00, capped = TRUE
+WHERE id = @id::uuid;
+
 -- name: IsClicked :one
 SELECT 1 FROM clicks
 WHERE
     campaign_id = @campaign_id::uuid AND
-    client_id = @client_id::uuid;
\ No newline at end of file
+    client_id = @client_id::uuid;
+
+-- name: GetBilledClicksCounters :many
+SELECT campaign_id, COUNT(*)::bigint AS count FROM clicks
+WHERE NOT capped
+GROUP BY campaign_id;
@@ -1,7 +1,13 @@
 -- name: CreateImpression :one
 INSERT INTO impressions (
-    campaign_id, client_id
-) VALUES (
-    @campaign_id::uuid, @client_id::uuid
+    campaign_id, client_id, date, cost
 )
+SELECT
+    campaigns.id, @client_id::uuid, @date::int, campaigns.cost_per_impression
+FROM campaigns
+WHERE campaigns.id = @campaign_id::uuid
 RETURNING *;
+
+-- name: GetImpressionsCounters :many
+SELECT campaign_id, client_id, date, COUNT(*)::bigint AS count FROM impressions
+GROUP BY campaign_id, client_id, date;
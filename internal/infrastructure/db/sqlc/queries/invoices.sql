@@ -0,0 +1,26 @@
+-- name: GetAdvertiserInvoiceItems :many
+SELECT
+    campaigns.id AS campaign_id,
+    campaigns.ad_title,
+    COALESCE(period_impressions.impressions_count, 0)::bigint AS impressions_count,
+    COALESCE(period_impressions.impressions_amount, 0)::decimal(12,2) AS impressions_amount,
+    COALESCE(period_clicks.clicks_count, 0)::bigint AS clicks_count,
+    COALESCE(period_clicks.clicks_amount, 0)::decimal(12,2) AS clicks_amount
+FROM campaigns
+LEFT JOIN (
+    SELECT impressions.campaign_id, COUNT(*) AS impressions_count, SUM(impressions.cost) AS impressions_amount
+    FROM impressions
+    WHERE impressions.date BETWEEN @from_date::int AND @to_date::int
+    GROUP BY impressions.campaign_id
+) AS period_impressions ON period_impressions.campaign_id = campaigns.id
+LEFT JOIN (
+    -- Capped clicks are free, so they are not billed
+    SELECT clicks.campaign_id, COUNT(*) AS clicks_count, SUM(clicks.cost) AS clicks_amount
+    FROM clicks
+    WHERE clicks.date BETWEEN @from_date::int AND @to_date::int AND NOT clicks.capped
+    GROUP BY clicks.campaign_id
+) AS period_clicks ON period_clicks.campaign_id = campaigns.id
+WHERE
+    campaigns.advertiser_id = @advertiser_id::uuid AND
+    (period_impressions.campaign_id IS NOT NULL OR period_clicks.campaign_id IS NOT NULL)
+ORDER BY campaigns.start_date, campaigns.id;
@@ -0,0 +1,40 @@
+-- name: TopUpAdvertiserBalance :one
+UPDATE advertisers
+SET balance = balance + @amount::decimal(12,2)
+WHERE id = @advertiser_id::uuid
+RETURNING *;
+
+-- name: CreateLedgerEntry :exec
+INSERT INTO ledger (
+    advertiser_id, campaign_id, operation, amount, date
+) VALUES (
+    @advertiser_id::uuid, sqlc.narg(campaign_id)::uuid, @operation::varchar, @amount::decimal(12,2), @date::int
+);
+
+-- name: ChargeCampaignAdvertiser :one
+-- Withdraws the amount from the balance of the campaign advertiser and records it in the ledger.
+-- If the balance is enforced, nothing is charged when the balance doesn't cover the amount
+WITH charged AS (
+    UPDATE advertisers
+    SET balance = balance - @amount::decimal(12,2)
+    FROM campaigns
+    WHERE
+        campaigns.id = @campaign_id::uuid AND advertisers.id = campaigns.advertiser_id AND
+        (NOT @enforce_balance::boolean OR advertisers.balance >= @amount::decimal(12,2))
+    RETURNING advertisers.id
+)
+INSERT INTO ledger (
+    advertiser_id, campaign_id, operation, amount, date
+)
+SELECT charged.id, @campaign_id::uuid, @operation::varchar, -@amount::decimal(12,2), @date::int
+FROM charged
+RETURNING *;
+
+-- name: GetLedgerByAdvertiserID :many
+SELECT * FROM ledger
+WHERE advertiser_id = @advertiser_id::uuid
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetAdvertisersBalances :many
+SELECT id, balance FROM advertisers;
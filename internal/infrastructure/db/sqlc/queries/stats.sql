@@ -0,0 +1,122 @@
+-- name: GetCampaignStats :one
+SELECT
+    (
+        SELECT COUNT(*) FROM impressions
+        WHERE impressions.campaign_id = campaigns.id
+    )::bigint AS impressions_count,
+    GREATEST(
+        (
+            SELECT COUNT(*) FROM impressions
+            WHERE impressions.campaign_id = campaigns.id
+        ) - campaigns.impressions_limit,
+        0
+    )::bigint AS impressions_overshoot,
+    (
+        SELECT COUNT(*) FROM clicks
+        WHERE clicks.campaign_id = campaigns.id
+    )::bigint AS clicks_count,
+    (
+        SELECT COUNT(*) FROM clicks
+        WHERE clicks.campaign_id = campaigns.id AND clicks.capped
+    )::bigint AS capped_clicks_count,
+    (
+        SELECT COALESCE(SUM(impressions.cost), 0) FROM impressions
+        WHERE impressions.campaign_id = campaigns.id
+    )::decimal(12,2) AS spent_impressions,
+    (
+        SELECT COALESCE(SUM(clicks.cost), 0) FROM clicks
+        WHERE clicks.campaign_id = campaigns.id
+    )::decimal(12,2) AS spent_clicks
+FROM campaigns
+WHERE campaigns.id = @campaign_id::uuid;
+
+-- name: GetCampaignDailyStats :many
+SELECT
+    days.date::int AS date,
+    COALESCE(daily_impressions.impressions_count, 0)::bigint AS impressions_count,
+    COALESCE(daily_clicks.clicks_count, 0)::bigint AS clicks_count,
+    COALESCE(daily_clicks.capped_clicks_count, 0)::bigint AS capped_clicks_count,
+    COALESCE(daily_impressions.spent_impressions, 0)::decimal(12,2) AS spent_impressions,
+    COALESCE(daily_clicks.spent_clicks, 0)::decimal(12,2) AS spent_clicks
+FROM campaigns
+CROSS JOIN LATERAL generate_series(
+    campaigns.start_date,
+    LEAST(campaigns.end_date, @cur_date::int)
+) AS days(date)
+LEFT JOIN (
+    SELECT impressions.date, COUNT(*) AS impressions_count, SUM(impressions.cost) AS spent_impressions
+    FROM impressions
+    WHERE impressions.campaign_id = @campaign_id::uuid
+    GROUP BY impressions.date
+) AS daily_impressions ON daily_impressions.date = days.date
+LEFT JOIN (
+    SELECT
+        clicks.date, COUNT(*) AS clicks_count,
+        COUNT(*) FILTER (WHERE clicks.capped) AS capped_clicks_count,
+        SUM(clicks.cost) AS spent_clicks
+    FROM clicks
+    WHERE clicks.campaign_id = @campaign_id::uuid
+    GROUP BY clicks.date
+) AS daily_clicks ON daily_clicks.date = days.date
+WHERE campaigns.id = @campaign_id::uuid
+ORDER BY days.date;
+
+-- name: GetAdvertiserDailyStats :many
+SELECT
+    days.date::int AS date,
+    COALESCE(daily_impressions.impressions_count, 0)::bigint AS impressions_count,
+    COALESCE(daily_clicks.clicks_count, 0)::bigint AS clicks_count,
+    COALESCE(daily_clicks.capped_clicks_count, 0)::bigint AS capped_clicks_count,
+    COALESCE(daily_impressions.spent_impressions, 0)::decimal(12,2) AS spent_impressions,
+    COALESCE(daily_clicks.spent_clicks, 0)::decimal(12,2) AS spent_clicks
+FROM generate_series(
+    (SELECT MIN(start_date) FROM campaigns WHERE campaigns.advertiser_id = @advertiser_id::uuid),
+    LEAST(
+        (SELECT MAX(end_date) FROM campaigns WHERE campaigns.advertiser_id = @advertiser_id::uuid),
+        @cur_date::int
+    )
+) AS days(date)
+LEFT JOIN (
+    SELECT impressions.date, COUNT(*) AS impressions_count, SUM(impressions.cost) AS spent_impressions
+    FROM impressions
+    JOIN campaigns ON campaigns.id = impressions.campaign_id
+    WHERE campaigns.advertiser_id = @advertiser_id::uuid
+    GROUP BY impressions.date
+) AS daily_impressions ON daily_impressions.date = days.date
+LEFT JOIN (
+    SELECT
+        clicks.date, COUNT(*) AS clicks_count,
+        COUNT(*) FILTER (WHERE clicks.capped) AS capped_clicks_count,
+        SUM(clicks.cost) AS spent_clicks
+    FROM clicks
+    JOIN campaigns ON campaigns.id = clicks.campaign_id
+    WHERE campaigns.advertiser_id = @advertiser_id::uuid
+    GROUP BY clicks.date
+) AS daily_clicks ON daily_clicks.date = days.date
+ORDER BY days.date;
+
+-- name: GetCampaignSpent :one
+SELECT
+    (
+        (SELECT COALESCE(SUM(impressions.cost), 0) FROM impressions WHERE impressions.campaign_id = @campaign_id::uuid) +
+        (SELECT COALESCE(SUM(clicks.cost), 0) FROM clicks WHERE clicks.campaign_id = @campaign_id::uuid)
+    )::decimal(12,2) AS spent_total,
+    (
+        (
+            SELECT COALESCE(SUM(impressions.cost), 0) FROM impressions
+            WHERE impressions.campaign_id = @campaign_id::uuid AND impressions.date = @cur_date::int
+        ) +
+        (
+            SELECT COALESCE(SUM(clicks.cost), 0) FROM clicks
+            WHERE clicks.campaign_id = @campaign_id::uuid AND clicks.date = @cur_date::int
+        )
+    )::decimal(12,2) AS spent_daily;
+
+-- name: GetSpentCounters :many
+SELECT events.campaign_id, events.date, COALESCE(SUM(events.cost), 0)::decimal(12,2) AS spent
+FROM (
+    SELECT impressions.campaign_id, impressions.date, impressions.cost FROM impressions
+    UNION ALL
+    SELECT clicks.campaign_id, clicks.date, clicks.cost FROM clicks
+) AS events
+GROUP BY events.campaign_id, events.date;
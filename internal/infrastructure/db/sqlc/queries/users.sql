@@ -1,9 +1,10 @@
 -- name: CreateUser :one
 INSERT INTO users (
-    id, login, age, location, gender
+    id, login, age, location, gender, interests
 ) VALUES (
     @id::uuid, @login::varchar,
-    @age::integer, @location::varchar, @gender::varchar
+    @age::integer, @location::varchar, @gender::varchar,
+    COALESCE(sqlc.narg(interests)::text[], '{}')
 )
 RETURNING *;
 
@@ -11,11 +12,25 @@ RETURNING *;
 SELECT * FROM users
 WHERE id = @id::uuid;
 
+-- name: GetUsersByFilter :many
+-- Filters clients for audience estimation, NULL filters match everyone
+SELECT * FROM users
+WHERE
+(sqlc.narg(age_from)::int IS NULL OR age >= sqlc.narg(age_from)::int) AND
+(sqlc.narg(age_to)::int IS NULL OR age <= sqlc.narg(age_to)::int) AND
+(sqlc.narg(genders)::varchar[] IS NULL OR gender = ANY(sqlc.narg(genders)::varchar[])) AND
+(sqlc.narg(locations)::varchar[] IS NULL OR location = ANY(sqlc.narg(locations)::varchar[])) AND
+(sqlc.narg(excluded_locations)::varchar[] IS NULL OR location <> ALL(sqlc.narg(excluded_locations)::varchar[])) AND
+(sqlc.narg(interests_any)::text[] IS NULL OR interests && sqlc.narg(interests_any)::text[]) AND
+(sqlc.narg(interests_all)::text[] IS NULL OR interests @> sqlc.narg(interests_all)::text[]) AND
+(sqlc.narg(client_ids)::uuid[] IS NULL OR id = ANY(sqlc.narg(client_ids)::uuid[]));
+
 -- name: UpdateUser :exec
 UPDATE users
 SET
 login = @login::varchar,
 age = @age::int,
 location = @location::varchar,
-gender = @gender::varchar
+gender = @gender::varchar,
+interests = COALESCE(sqlc.narg(interests)::text[], interests)
 WHERE id = @id::uuid;
\ No newline at end of file
@@ -49,14 +49,14 @@ func (q *Queries) CreateMLScore(ctx context.Context, arg CreateMLScoreParams) er
 }
 
 const getAdvertiserByID = `-- name: GetAdvertiserByID :one
-SELECT id, name FROM advertisers
+SELECT id, name, balance FROM advertisers
 WHERE id = $1::uuid
 `
 
 func (q *Queries) GetAdvertiserByID(ctx context.Context, id uuid.UUID) (Advertiser, error) {
 	row := q.db.QueryRow(ctx, getAdvertiserByID, id)
 	var i Advertiser
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Balance)
 	return i, err
 }
 
@@ -79,6 +79,56 @@ func (q *Queries) GetMLScoreByIDs(ctx context.Context, arg GetMLScoreByIDsParams
 	return i, err
 }
 
+const getMLScoresByAdvertiserID = `-- name: GetMLScoresByAdvertiserID :many
+SELECT client_id, advertiser_id, score FROM ml_scores
+WHERE advertiser_id = $1::uuid
+`
+
+func (q *Queries) GetMLScoresByAdvertiserID(ctx context.Context, advertiserID uuid.UUID) ([]MlScore, error) {
+	rows, err := q.db.Query(ctx, getMLScoresByAdvertiserID, advertiserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MlScore
+	for rows.Next() {
+		var i MlScore
+		if err := rows.Scan(&i.ClientID, &i.AdvertiserID, &i.Score); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMLScoresByClientID = `-- name: GetMLScoresByClientID :many
+SELECT client_id, advertiser_id, score FROM ml_scores
+WHERE client_id = $1::uuid
+`
+
+func (q *Queries) GetMLScoresByClientID(ctx context.Context, clientID uuid.UUID) ([]MlScore, error) {
+	rows, err := q.db.Query(ctx, getMLScoresByClientID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MlScore
+	for rows.Next() {
+		var i MlScore
+		if err := rows.Scan(&i.ClientID, &i.AdvertiserID, &i.Score); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateAdvertiser = `-- name: UpdateAdvertiser :exec
 UPDATE advertisers
 SET name = $1::varchar
@@ -0,0 +1,223 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: audiences.sql
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const addAudienceClients = `-- name: AddAudienceClients :exec
+INSERT INTO audience_clients (audience_id, client_id)
+SELECT $1::uuid, unnest($2::uuid[])
+ON CONFLICT DO NOTHING
+`
+
+type AddAudienceClientsParams struct {
+	AudienceID uuid.UUID
+	ClientIds  []uuid.UUID
+}
+
+func (q *Queries) AddAudienceClients(ctx context.Context, arg AddAudienceClientsParams) error {
+	_, err := q.db.Exec(ctx, addAudienceClients, arg.AudienceID, arg.ClientIds)
+	return err
+}
+
+const createAudience = `-- name: CreateAudience :one
+INSERT INTO audiences (advertiser_id, name, targeting)
+VALUES ($1::uuid, $2::varchar, $3::jsonb)
+RETURNING id, advertiser_id, name, targeting, created_at
+`
+
+type CreateAudienceParams struct {
+	AdvertiserID uuid.UUID
+	Name         string
+	Targeting    []byte
+}
+
+func (q *Queries) CreateAudience(ctx context.Context, arg CreateAudienceParams) (Audience, error) {
+	row := q.db.QueryRow(ctx, createAudience, arg.AdvertiserID, arg.Name, arg.Targeting)
+	var i Audience
+	err := row.Scan(
+		&i.ID,
+		&i.AdvertiserID,
+		&i.Name,
+		&i.Targeting,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAudienceClients = `-- name: DeleteAudienceClients :exec
+DELETE FROM audience_clients
+WHERE audience_id = $1::uuid
+`
+
+func (q *Queries) DeleteAudienceClients(ctx context.Context, audienceID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAudienceClients, audienceID)
+	return err
+}
+
+const deleteUnusedAudience = `-- name: DeleteUnusedAudience :execrows
+DELETE FROM audiences
+WHERE id = $1::uuid AND NOT EXISTS (
+    SELECT 1 FROM campaigns
+    WHERE
+        campaigns.audience_id = $1::uuid AND
+        campaigns.status <> 'archived' AND
+        campaigns.deleted_at IS NULL
+)
+`
+
+// Audience used by campaigns that are not archived or deleted is kept
+func (q *Queries) DeleteUnusedAudience(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUnusedAudience, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAudienceByID = `-- name: GetAudienceByID :one
+SELECT id, advertiser_id, name, targeting, created_at FROM audiences
+WHERE id = $1::uuid
+`
+
+func (q *Queries) GetAudienceByID(ctx context.Context, id uuid.UUID) (Audience, error) {
+	row := q.db.QueryRow(ctx, getAudienceByID, id)
+	var i Audience
+	err := row.Scan(
+		&i.ID,
+		&i.AdvertiserID,
+		&i.Name,
+		&i.Targeting,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAudienceClients = `-- name: GetAudienceClients :many
+SELECT audience_id, client_id FROM audience_clients
+WHERE audience_id = ANY($1::uuid[])
+ORDER BY audience_id, client_id
+`
+
+func (q *Queries) GetAudienceClients(ctx context.Context, audienceIds []uuid.UUID) ([]AudienceClient, error) {
+	rows, err := q.db.Query(ctx, getAudienceClients, audienceIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AudienceClient
+	for rows.Next() {
+		var i AudienceClient
+		if err := rows.Scan(&i.AudienceID, &i.ClientID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAudiencesByAdvertiserID = `-- name: GetAudiencesByAdvertiserID :many
+SELECT id, advertiser_id, name, targeting, created_at FROM audiences
+WHERE advertiser_id = $3::uuid
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2
+`
+
+type GetAudiencesByAdvertiserIDParams struct {
+	Limit        int32
+	Offset       int32
+	AdvertiserID uuid.UUID
+}
+
+func (q *Queries) GetAudiencesByAdvertiserID(ctx context.Context, arg GetAudiencesByAdvertiserIDParams) ([]Audience, error) {
+	rows, err := q.db.Query(ctx, getAudiencesByAdvertiserID, arg.Limit, arg.Offset, arg.AdvertiserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Audience
+	for rows.Next() {
+		var i Audience
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdvertiserID,
+			&i.Name,
+			&i.Targeting,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAudiencesByIDs = `-- name: GetAudiencesByIDs :many
+SELECT id, advertiser_id, name, targeting, created_at FROM audiences
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetAudiencesByIDs(ctx context.Context, ids []uuid.UUID) ([]Audience, error) {
+	rows, err := q.db.Query(ctx, getAudiencesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Audience
+	for rows.Next() {
+		var i Audience
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdvertiserID,
+			&i.Name,
+			&i.Targeting,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAudience = `-- name: UpdateAudience :one
+UPDATE audiences
+SET name = $1::varchar, targeting = $2::jsonb
+WHERE id = $3::uuid
+RETURNING id, advertiser_id, name, targeting, created_at
+`
+
+type UpdateAudienceParams struct {
+	Name      string
+	Targeting []byte
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateAudience(ctx context.Context, arg UpdateAudienceParams) (Audience, error) {
+	row := q.db.QueryRow(ctx, updateAudience, arg.Name, arg.Targeting, arg.ID)
+	var i Audience
+	err := row.Scan(
+		&i.ID,
+		&i.AdvertiserID,
+		&i.Name,
+		&i.Targeting,
+		&i.CreatedAt,
+	)
+	return i, err
+}
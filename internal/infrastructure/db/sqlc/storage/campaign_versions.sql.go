@@ -0,0 +1,104 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: campaign_versions.sql
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createCampaignVersion = `-- name: CreateCampaignVersion :one
+INSERT INTO campaign_versions (campaign_id, version, date, snapshot)
+VALUES (
+    $1::uuid,
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM campaign_versions WHERE campaign_id = $1::uuid),
+    $2::int,
+    $3::jsonb
+)
+RETURNING campaign_id, version, date, snapshot, created_at
+`
+
+type CreateCampaignVersionParams struct {
+	CampaignID uuid.UUID
+	Date       int32
+	Snapshot   []byte
+}
+
+func (q *Queries) CreateCampaignVersion(ctx context.Context, arg CreateCampaignVersionParams) (CampaignVersion, error) {
+	row := q.db.QueryRow(ctx, createCampaignVersion, arg.CampaignID, arg.Date, arg.Snapshot)
+	var i CampaignVersion
+	err := row.Scan(
+		&i.CampaignID,
+		&i.Version,
+		&i.Date,
+		&i.Snapshot,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCampaignVersion = `-- name: GetCampaignVersion :one
+SELECT campaign_id, version, date, snapshot, created_at FROM campaign_versions
+WHERE campaign_id = $1::uuid AND version = $2::int
+`
+
+type GetCampaignVersionParams struct {
+	CampaignID uuid.UUID
+	Version    int32
+}
+
+func (q *Queries) GetCampaignVersion(ctx context.Context, arg GetCampaignVersionParams) (CampaignVersion, error) {
+	row := q.db.QueryRow(ctx, getCampaignVersion, arg.CampaignID, arg.Version)
+	var i CampaignVersion
+	err := row.Scan(
+		&i.CampaignID,
+		&i.Version,
+		&i.Date,
+		&i.Snapshot,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCampaignVersions = `-- name: GetCampaignVersions :many
+SELECT campaign_id, version, date, snapshot, created_at FROM campaign_versions
+WHERE campaign_id = $3::uuid
+ORDER BY version DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetCampaignVersionsParams struct {
+	Limit      int32
+	Offset     int32
+	CampaignID uuid.UUID
+}
+
+func (q *Queries) GetCampaignVersions(ctx context.Context, arg GetCampaignVersionsParams) ([]CampaignVersion, error) {
+	rows, err := q.db.Query(ctx, getCampaignVersions, arg.Limit, arg.Offset, arg.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CampaignVersion
+	for rows.Next() {
+		var i CampaignVersion
+		if err := rows.Scan(
+			&i.CampaignID,
+			&i.Version,
+			&i.Date,
+			&i.Snapshot,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
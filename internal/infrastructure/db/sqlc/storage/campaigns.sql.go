@@ -18,15 +18,23 @@ INSERT INTO campaigns (
     impressions_limit, clicks_limit,
     cost_per_impression, cost_per_click,
     ad_title, ad_text,
-    start_date, end_date
+    start_date, end_date,
+    frequency_cap_total, frequency_cap_daily,
+    budget_total, budget_daily,
+    pacing, status,
+    audience_id
 ) VALUES (
     $1::uuid,
     $2::bigint, $3::bigint,
     $4::decimal(10,2), $5::decimal(10,2),
     $6::varchar, $7::varchar,
-    $8::int, $9::int
+    $8::int, $9::int,
+    COALESCE($10::int, 1), COALESCE($11::int, 0),
+    COALESCE($12::decimal(10,2), 0), COALESCE($13::decimal(10,2), 0),
+    COALESCE($14::varchar, 'asap'), COALESCE($15::varchar, 'active'),
+    $16::uuid
 )
-RETURNING id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id
+RETURNING id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id, frequency_cap_total, frequency_cap_daily, budget_total, budget_daily, pacing, status, deleted_at, audience_id
 `
 
 type CreateCampaignParams struct {
@@ -39,6 +47,13 @@ type CreateCampaignParams struct {
 	AdText            string
 	StartDate         int32
 	EndDate           int32
+	FrequencyCapTotal pgtype.Int4
+	FrequencyCapDaily pgtype.Int4
+	BudgetTotal       pgtype.Numeric
+	BudgetDaily       pgtype.Numeric
+	Pacing            pgtype.Text
+	Status            pgtype.Text
+	AudienceID        pgtype.UUID
 }
 
 func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams) (Campaign, error) {
@@ -52,6 +67,13 @@ func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams)
 		arg.AdText,
 		arg.StartDate,
 		arg.EndDate,
+		arg.FrequencyCapTotal,
+		arg.FrequencyCapDaily,
+		arg.BudgetTotal,
+		arg.BudgetDaily,
+		arg.Pacing,
+		arg.Status,
+		arg.AudienceID,
 	)
 	var i Campaign
 	err := row.Scan(
@@ -66,6 +88,14 @@ func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams)
 		&i.StartDate,
 		&i.EndDate,
 		&i.PicID,
+		&i.FrequencyCapTotal,
+		&i.FrequencyCapDaily,
+		&i.BudgetTotal,
+		&i.BudgetDaily,
+		&i.Pacing,
+		&i.Status,
+		&i.DeletedAt,
+		&i.AudienceID,
 	)
 	return i, err
 }
@@ -75,22 +105,35 @@ INSERT INTO campaigns_targeting (
     campaign_id,
     gender,
     age_from, age_to,
-    location
+    location,
+    genders, locations, excluded_locations,
+    interests, interests_mode,
+    rules
 ) VALUES (
     $1::uuid,
     COALESCE($2::varchar, NULL),
     COALESCE($3::int, NULL), COALESCE($4::int, NULL),
-    COALESCE($5::varchar, NULL)
+    COALESCE($5::varchar, NULL),
+    COALESCE($6::text[], '{}'), COALESCE($7::text[], '{}'),
+    COALESCE($8::text[], '{}'),
+    COALESCE($9::text[], '{}'), COALESCE($10::varchar, NULL),
+    $11::jsonb
 )
-RETURNING id, campaign_id, gender, age_from, age_to, location
+RETURNING id, campaign_id, gender, age_from, age_to, location, genders, locations, excluded_locations, interests, interests_mode, rules
 `
 
 type CreateCampaignTargetingParams struct {
-	CampaignID uuid.UUID
-	Gender     pgtype.Text
-	AgeFrom    pgtype.Int4
-	AgeTo      pgtype.Int4
-	Location   pgtype.Text
+	CampaignID        uuid.UUID
+	Gender            pgtype.Text
+	AgeFrom           pgtype.Int4
+	AgeTo             pgtype.Int4
+	Location          pgtype.Text
+	Genders           []string
+	Locations         []string
+	ExcludedLocations []string
+	Interests         []string
+	InterestsMode     pgtype.Text
+	Rules             []byte
 }
 
 func (q *Queries) CreateCampaignTargeting(ctx context.Context, arg CreateCampaignTargetingParams) (CampaignsTargeting, error) {
@@ -100,6 +143,12 @@ func (q *Queries) CreateCampaignTargeting(ctx context.Context, arg CreateCampaig
 		arg.AgeFrom,
 		arg.AgeTo,
 		arg.Location,
+		arg.Genders,
+		arg.Locations,
+		arg.ExcludedLocations,
+		arg.Interests,
+		arg.InterestsMode,
+		arg.Rules,
 	)
 	var i CampaignsTargeting
 	err := row.Scan(
@@ -109,6 +158,12 @@ func (q *Queries) CreateCampaignTargeting(ctx context.Context, arg CreateCampaig
 		&i.AgeFrom,
 		&i.AgeTo,
 		&i.Location,
+		&i.Genders,
+		&i.Locations,
+		&i.ExcludedLocations,
+		&i.Interests,
+		&i.InterestsMode,
+		&i.Rules,
 	)
 	return i, err
 }
@@ -123,6 +178,73 @@ func (q *Queries) DeleteCampaignByID(ctx context.Context, campaignID uuid.UUID)
 	return err
 }
 
+const getActiveCampaignsWithTargeting = `-- name: GetActiveCampaignsWithTargeting :many
+SELECT campaigns.id, campaigns.advertiser_id, campaigns.impressions_limit, campaigns.clicks_limit, campaigns.cost_per_impression, campaigns.cost_per_click, campaigns.ad_title, campaigns.ad_text, campaigns.start_date, campaigns.end_date, campaigns.pic_id, campaigns.frequency_cap_total, campaigns.frequency_cap_daily, campaigns.budget_total, campaigns.budget_daily, campaigns.pacing, campaigns.status, campaigns.deleted_at, campaigns.audience_id, campaigns_targeting.id, campaigns_targeting.campaign_id, campaigns_targeting.gender, campaigns_targeting.age_from, campaigns_targeting.age_to, campaigns_targeting.location, campaigns_targeting.genders, campaigns_targeting.locations, campaigns_targeting.excluded_locations, campaigns_targeting.interests, campaigns_targeting.interests_mode, campaigns_targeting.rules FROM campaigns
+JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+WHERE
+    campaigns.deleted_at IS NULL AND
+    campaigns.status = 'active' AND
+    campaigns.start_date <= $1::int AND
+    campaigns.end_date >= $1::int
+`
+
+type GetActiveCampaignsWithTargetingRow struct {
+	Campaign           Campaign
+	CampaignsTargeting CampaignsTargeting
+}
+
+func (q *Queries) GetActiveCampaignsWithTargeting(ctx context.Context, curDate int32) ([]GetActiveCampaignsWithTargetingRow, error) {
+	rows, err := q.db.Query(ctx, getActiveCampaignsWithTargeting, curDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveCampaignsWithTargetingRow
+	for rows.Next() {
+		var i GetActiveCampaignsWithTargetingRow
+		if err := rows.Scan(
+			&i.Campaign.ID,
+			&i.Campaign.AdvertiserID,
+			&i.Campaign.ImpressionsLimit,
+			&i.Campaign.ClicksLimit,
+			&i.Campaign.CostPerImpression,
+			&i.Campaign.CostPerClick,
+			&i.Campaign.AdTitle,
+			&i.Campaign.AdText,
+			&i.Campaign.StartDate,
+			&i.Campaign.EndDate,
+			&i.Campaign.PicID,
+			&i.Campaign.FrequencyCapTotal,
+			&i.Campaign.FrequencyCapDaily,
+			&i.Campaign.BudgetTotal,
+			&i.Campaign.BudgetDaily,
+			&i.Campaign.Pacing,
+			&i.Campaign.Status,
+			&i.Campaign.DeletedAt,
+			&i.Campaign.AudienceID,
+			&i.CampaignsTargeting.ID,
+			&i.CampaignsTargeting.CampaignID,
+			&i.CampaignsTargeting.Gender,
+			&i.CampaignsTargeting.AgeFrom,
+			&i.CampaignsTargeting.AgeTo,
+			&i.CampaignsTargeting.Location,
+			&i.CampaignsTargeting.Genders,
+			&i.CampaignsTargeting.Locations,
+			&i.CampaignsTargeting.ExcludedLocations,
+			&i.CampaignsTargeting.Interests,
+			&i.CampaignsTargeting.InterestsMode,
+			&i.CampaignsTargeting.Rules,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCampaignPicID = `-- name: GetCampaignPicID :one
 SELECT pic_id FROM campaigns
 WHERE id = $1::uuid
@@ -136,58 +258,106 @@ func (q *Queries) GetCampaignPicID(ctx context.Context, campaignID uuid.UUID) (p
 }
 
 const getCampaignWithTargetingByID = `-- name: GetCampaignWithTargetingByID :one
-SELECT campaigns.id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id, campaigns_targeting.id, campaign_id, gender, age_from, age_to, location FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
-WHERE campaigns.id = $1::uuid
+SELECT campaigns.id, campaigns.advertiser_id, campaigns.impressions_limit, campaigns.clicks_limit, campaigns.cost_per_impression, campaigns.cost_per_click, campaigns.ad_title, campaigns.ad_text, campaigns.start_date, campaigns.end_date, campaigns.pic_id, campaigns.frequency_cap_total, campaigns.frequency_cap_daily, campaigns.budget_total, campaigns.budget_daily, campaigns.pacing, campaigns.status, campaigns.deleted_at, campaigns.audience_id, campaigns_targeting.id, campaigns_targeting.campaign_id, campaigns_targeting.gender, campaigns_targeting.age_from, campaigns_targeting.age_to, campaigns_targeting.location, campaigns_targeting.genders, campaigns_targeting.locations, campaigns_targeting.excluded_locations, campaigns_targeting.interests, campaigns_targeting.interests_mode, campaigns_targeting.rules FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+WHERE campaigns.id = $1::uuid AND campaigns.deleted_at IS NULL
 `
 
 type GetCampaignWithTargetingByIDRow struct {
-	ID                uuid.UUID
-	AdvertiserID      uuid.UUID
-	ImpressionsLimit  int64
-	ClicksLimit       int64
-	CostPerImpression pgtype.Numeric
-	CostPerClick      pgtype.Numeric
-	AdTitle           string
-	AdText            string
-	StartDate         int32
-	EndDate           int32
-	PicID             pgtype.Text
-	ID_2              uuid.UUID
-	CampaignID        uuid.UUID
-	Gender            pgtype.Text
-	AgeFrom           pgtype.Int4
-	AgeTo             pgtype.Int4
-	Location          pgtype.Text
+	Campaign           Campaign
+	CampaignsTargeting CampaignsTargeting
 }
 
 func (q *Queries) GetCampaignWithTargetingByID(ctx context.Context, campaignID uuid.UUID) (GetCampaignWithTargetingByIDRow, error) {
 	row := q.db.QueryRow(ctx, getCampaignWithTargetingByID, campaignID)
 	var i GetCampaignWithTargetingByIDRow
 	err := row.Scan(
-		&i.ID,
-		&i.AdvertiserID,
-		&i.ImpressionsLimit,
-		&i.ClicksLimit,
-		&i.CostPerImpression,
-		&i.CostPerClick,
-		&i.AdTitle,
-		&i.AdText,
-		&i.StartDate,
-		&i.EndDate,
-		&i.PicID,
-		&i.ID_2,
-		&i.CampaignID,
-		&i.Gender,
-		&i.AgeFrom,
-		&i.AgeTo,
-		&i.Location,
+		&i.Campaign.ID,
+		&i.Campaign.AdvertiserID,
+		&i.Campaign.ImpressionsLimit,
+		&i.Campaign.ClicksLimit,
+		&i.Campaign.CostPerImpression,
+		&i.Campaign.CostPerClick,
+		&i.Campaign.AdTitle,
+		&i.Campaign.AdText,
+		&i.Campaign.StartDate,
+		&i.Campaign.EndDate,
+		&i.Campaign.PicID,
+		&i.Campaign.FrequencyCapTotal,
+		&i.Campaign.FrequencyCapDaily,
+		&i.Campaign.BudgetTotal,
+		&i.Campaign.BudgetDaily,
+		&i.Campaign.Pacing,
+		&i.Campaign.Status,
+		&i.Campaign.DeletedAt,
+		&i.Campaign.AudienceID,
+		&i.CampaignsTargeting.ID,
+		&i.CampaignsTargeting.CampaignID,
+		&i.CampaignsTargeting.Gender,
+		&i.CampaignsTargeting.AgeFrom,
+		&i.CampaignsTargeting.AgeTo,
+		&i.CampaignsTargeting.Location,
+		&i.CampaignsTargeting.Genders,
+		&i.CampaignsTargeting.Locations,
+		&i.CampaignsTargeting.ExcludedLocations,
+		&i.CampaignsTargeting.Interests,
+		&i.CampaignsTargeting.InterestsMode,
+		&i.CampaignsTargeting.Rules,
+	)
+	return i, err
+}
+
+const getCampaignWithTargetingByIDIncludingDeleted = `-- name: GetCampaignWithTargetingByIDIncludingDeleted :one
+SELECT campaigns.id, campaigns.advertiser_id, campaigns.impressions_limit, campaigns.clicks_limit, campaigns.cost_per_impression, campaigns.cost_per_click, campaigns.ad_title, campaigns.ad_text, campaigns.start_date, campaigns.end_date, campaigns.pic_id, campaigns.frequency_cap_total, campaigns.frequency_cap_daily, campaigns.budget_total, campaigns.budget_daily, campaigns.pacing, campaigns.status, campaigns.deleted_at, campaigns.audience_id, campaigns_targeting.id, campaigns_targeting.campaign_id, campaigns_targeting.gender, campaigns_targeting.age_from, campaigns_targeting.age_to, campaigns_targeting.location, campaigns_targeting.genders, campaigns_targeting.locations, campaigns_targeting.excluded_locations, campaigns_targeting.interests, campaigns_targeting.interests_mode, campaigns_targeting.rules FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+WHERE campaigns.id = $1::uuid
+`
+
+type GetCampaignWithTargetingByIDIncludingDeletedRow struct {
+	Campaign           Campaign
+	CampaignsTargeting CampaignsTargeting
+}
+
+func (q *Queries) GetCampaignWithTargetingByIDIncludingDeleted(ctx context.Context, campaignID uuid.UUID) (GetCampaignWithTargetingByIDIncludingDeletedRow, error) {
+	row := q.db.QueryRow(ctx, getCampaignWithTargetingByIDIncludingDeleted, campaignID)
+	var i GetCampaignWithTargetingByIDIncludingDeletedRow
+	err := row.Scan(
+		&i.Campaign.ID,
+		&i.Campaign.AdvertiserID,
+		&i.Campaign.ImpressionsLimit,
+		&i.Campaign.ClicksLimit,
+		&i.Campaign.CostPerImpression,
+		&i.Campaign.CostPerClick,
+		&i.Campaign.AdTitle,
+		&i.Campaign.AdText,
+		&i.Campaign.StartDate,
+		&i.Campaign.EndDate,
+		&i.Campaign.PicID,
+		&i.Campaign.FrequencyCapTotal,
+		&i.Campaign.FrequencyCapDaily,
+		&i.Campaign.BudgetTotal,
+		&i.Campaign.BudgetDaily,
+		&i.Campaign.Pacing,
+		&i.Campaign.Status,
+		&i.Campaign.DeletedAt,
+		&i.Campaign.AudienceID,
+		&i.CampaignsTargeting.ID,
+		&i.CampaignsTargeting.CampaignID,
+		&i.CampaignsTargeting.Gender,
+		&i.CampaignsTargeting.AgeFrom,
+		&i.CampaignsTargeting.AgeTo,
+		&i.CampaignsTargeting.Location,
+		&i.CampaignsTargeting.Genders,
+		&i.CampaignsTargeting.Locations,
+		&i.CampaignsTargeting.ExcludedLocations,
+		&i.CampaignsTargeting.Interests,
+		&i.CampaignsTargeting.InterestsMode,
+		&i.CampaignsTargeting.Rules,
 	)
 	return i, err
 }
 
 const getCampaignsWithTargetingByAdvertiserID = `-- name: GetCampaignsWithTargetingByAdvertiserID :many
-SELECT campaigns.id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id, campaigns_targeting.id, campaign_id, gender, age_from, age_to, location FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
-WHERE advertiser_id = $3::uuid
+SELECT campaigns.id, campaigns.advertiser_id, campaigns.impressions_limit, campaigns.clicks_limit, campaigns.cost_per_impression, campaigns.cost_per_click, campaigns.ad_title, campaigns.ad_text, campaigns.start_date, campaigns.end_date, campaigns.pic_id, campaigns.frequency_cap_total, campaigns.frequency_cap_daily, campaigns.budget_total, campaigns.budget_daily, campaigns.pacing, campaigns.status, campaigns.deleted_at, campaigns.audience_id, campaigns_targeting.id, campaigns_targeting.campaign_id, campaigns_targeting.gender, campaigns_targeting.age_from, campaigns_targeting.age_to, campaigns_targeting.location, campaigns_targeting.genders, campaigns_targeting.locations, campaigns_targeting.excluded_locations, campaigns_targeting.interests, campaigns_targeting.interests_mode, campaigns_targeting.rules FROM campaigns JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
+WHERE advertiser_id = $3::uuid AND campaigns.deleted_at IS NULL
 LIMIT $1 OFFSET $2
 `
 
@@ -198,23 +368,8 @@ type GetCampaignsWithTargetingByAdvertiserIDParams struct {
 }
 
 type GetCampaignsWithTargetingByAdvertiserIDRow struct {
-	ID                uuid.UUID
-	AdvertiserID      uuid.UUID
-	ImpressionsLimit  int64
-	ClicksLimit       int64
-	CostPerImpression pgtype.Numeric
-	CostPerClick      pgtype.Numeric
-	AdTitle           string
-	AdText            string
-	StartDate         int32
-	EndDate           int32
-	PicID             pgtype.Text
-	ID_2              uuid.UUID
-	CampaignID        uuid.UUID
-	Gender            pgtype.Text
-	AgeFrom           pgtype.Int4
-	AgeTo             pgtype.Int4
-	Location          pgtype.Text
+	Campaign           Campaign
+	CampaignsTargeting CampaignsTargeting
 }
 
 func (q *Queries) GetCampaignsWithTargetingByAdvertiserID(ctx context.Context, arg GetCampaignsWithTargetingByAdvertiserIDParams) ([]GetCampaignsWithTargetingByAdvertiserIDRow, error) {
@@ -227,23 +382,37 @@ func (q *Queries) GetCampaignsWithTargetingByAdvertiserID(ctx context.Context, a
 	for rows.Next() {
 		var i GetCampaignsWithTargetingByAdvertiserIDRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.AdvertiserID,
-			&i.ImpressionsLimit,
-			&i.ClicksLimit,
-			&i.CostPerImpression,
-			&i.CostPerClick,
-			&i.AdTitle,
-			&i.AdText,
-			&i.StartDate,
-			&i.EndDate,
-			&i.PicID,
-			&i.ID_2,
-			&i.CampaignID,
-			&i.Gender,
-			&i.AgeFrom,
-			&i.AgeTo,
-			&i.Location,
+			&i.Campaign.ID,
+			&i.Campaign.AdvertiserID,
+			&i.Campaign.ImpressionsLimit,
+			&i.Campaign.ClicksLimit,
+			&i.Campaign.CostPerImpression,
+			&i.Campaign.CostPerClick,
+			&i.Campaign.AdTitle,
+			&i.Campaign.AdText,
+			&i.Campaign.StartDate,
+			&i.Campaign.EndDate,
+			&i.Campaign.PicID,
+			&i.Campaign.FrequencyCapTotal,
+			&i.Campaign.FrequencyCapDaily,
+			&i.Campaign.BudgetTotal,
+			&i.Campaign.BudgetDaily,
+			&i.Campaign.Pacing,
+			&i.Campaign.Status,
+			&i.Campaign.DeletedAt,
+			&i.Campaign.AudienceID,
+			&i.CampaignsTargeting.ID,
+			&i.CampaignsTargeting.CampaignID,
+			&i.CampaignsTargeting.Gender,
+			&i.CampaignsTargeting.AgeFrom,
+			&i.CampaignsTargeting.AgeTo,
+			&i.CampaignsTargeting.Location,
+			&i.CampaignsTargeting.Genders,
+			&i.CampaignsTargeting.Locations,
+			&i.CampaignsTargeting.ExcludedLocations,
+			&i.CampaignsTargeting.Interests,
+			&i.CampaignsTargeting.InterestsMode,
+			&i.CampaignsTargeting.Rules,
 		); err != nil {
 			return nil, err
 		}
@@ -255,76 +424,65 @@ func (q *Queries) GetCampaignsWithTargetingByAdvertiserID(ctx context.Context, a
 	return items, nil
 }
 
-const getRelativeAd = `-- name: GetRelativeAd :one
-SELECT campaigns.id, campaigns.advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id, campaigns_targeting.id, campaign_id, gender, age_from, age_to, location, client_id, ml_scores.advertiser_id, score FROM campaigns 
-JOIN campaigns_targeting ON campaigns.id = campaigns_targeting.campaign_id
-JOIN ml_scores ON campaigns.advertiser_id = ml_scores.advertiser_id
-WHERE 
-    NOT EXISTS (
-        SELECT 1 FROM impressions 
-        WHERE impressions.campaign_id = campaigns.id 
-          AND impressions.client_id = $1::uuid
-    ) AND
-    (
-        SELECT COUNT(*) FROM impressions 
-        WHERE impressions.campaign_id = campaigns.id
-    ) < campaigns.impressions_limit
-    AND
-    (gender = $2::varchar OR gender = 'ALL' OR gender IS NULL) AND
-    (
-        ((age_from IS NULL AND age_to >= $3::int) OR 
-         (age_to IS NULL AND age_from <= $3::int) OR 
-         (age_from IS NULL AND age_to IS NULL)) OR
-        (age_from <= $3::int AND age_to >= $3::int)
-    ) AND
-    (location IS NULL OR location = $4::varchar) AND
-    ml_scores.client_id = $1::uuid AND
-    campaigns.start_date <= $5::int AND 
-    campaigns.end_date >= $5::int
-ORDER BY score DESC, cost_per_impression DESC
-LIMIT 1
+const getUnfinishedCampaignIDs = `-- name: GetUnfinishedCampaignIDs :many
+SELECT id FROM campaigns
+WHERE deleted_at IS NULL AND end_date >= $1::int
 `
 
-type GetRelativeAdParams struct {
-	ClientID uuid.UUID
-	Gender   string
-	Age      int32
-	Location string
-	CurDate  int32
+// Campaigns that still can be shown, their counters are kept in redis
+func (q *Queries) GetUnfinishedCampaignIDs(ctx context.Context, curDate int32) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getUnfinishedCampaignIDs, curDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-type GetRelativeAdRow struct {
-	ID                uuid.UUID
-	AdvertiserID      uuid.UUID
-	ImpressionsLimit  int64
-	ClicksLimit       int64
-	CostPerImpression pgtype.Numeric
-	CostPerClick      pgtype.Numeric
-	AdTitle           string
-	AdText            string
-	StartDate         int32
-	EndDate           int32
-	PicID             pgtype.Text
-	ID_2              uuid.UUID
-	CampaignID        uuid.UUID
-	Gender            pgtype.Text
-	AgeFrom           pgtype.Int4
-	AgeTo             pgtype.Int4
-	Location          pgtype.Text
-	ClientID          uuid.UUID
-	AdvertiserID_2    uuid.UUID
-	Score             int32
+const setCampaignPicture = `-- name: SetCampaignPicture :exec
+UPDATE campaigns
+SET
+    pic_id = $1::varchar
+WHERE
+    id = $2::uuid
+`
+
+type SetCampaignPictureParams struct {
+	PictureID  string
+	CampaignID uuid.UUID
 }
 
-func (q *Queries) GetRelativeAd(ctx context.Context, arg GetRelativeAdParams) (GetRelativeAdRow, error) {
-	row := q.db.QueryRow(ctx, getRelativeAd,
-		arg.ClientID,
-		arg.Gender,
-		arg.Age,
-		arg.Location,
-		arg.CurDate,
-	)
-	var i GetRelativeAdRow
+func (q *Queries) SetCampaignPicture(ctx context.Context, arg SetCampaignPictureParams) error {
+	_, err := q.db.Exec(ctx, setCampaignPicture, arg.PictureID, arg.CampaignID)
+	return err
+}
+
+const setCampaignStatus = `-- name: SetCampaignStatus :one
+UPDATE campaigns
+SET status = $1::varchar
+WHERE id = $2::uuid
+RETURNING id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id, frequency_cap_total, frequency_cap_daily, budget_total, budget_daily, pacing, status, deleted_at, audience_id
+`
+
+type SetCampaignStatusParams struct {
+	Status     string
+	CampaignID uuid.UUID
+}
+
+func (q *Queries) SetCampaignStatus(ctx context.Context, arg SetCampaignStatusParams) (Campaign, error) {
+	row := q.db.QueryRow(ctx, setCampaignStatus, arg.Status, arg.CampaignID)
+	var i Campaign
 	err := row.Scan(
 		&i.ID,
 		&i.AdvertiserID,
@@ -337,34 +495,26 @@ func (q *Queries) GetRelativeAd(ctx context.Context, arg GetRelativeAdParams) (G
 		&i.StartDate,
 		&i.EndDate,
 		&i.PicID,
-		&i.ID_2,
-		&i.CampaignID,
-		&i.Gender,
-		&i.AgeFrom,
-		&i.AgeTo,
-		&i.Location,
-		&i.ClientID,
-		&i.AdvertiserID_2,
-		&i.Score,
+		&i.FrequencyCapTotal,
+		&i.FrequencyCapDaily,
+		&i.BudgetTotal,
+		&i.BudgetDaily,
+		&i.Pacing,
+		&i.Status,
+		&i.DeletedAt,
+		&i.AudienceID,
 	)
 	return i, err
 }
 
-const setCampaignPicture = `-- name: SetCampaignPicture :exec
+const softDeleteCampaignByID = `-- name: SoftDeleteCampaignByID :exec
 UPDATE campaigns
-SET
-    pic_id = $1::varchar
-WHERE
-    id = $2::uuid
+SET deleted_at = NOW()
+WHERE id = $1::uuid AND deleted_at IS NULL
 `
 
-type SetCampaignPictureParams struct {
-	PictureID  string
-	CampaignID uuid.UUID
-}
-
-func (q *Queries) SetCampaignPicture(ctx context.Context, arg SetCampaignPictureParams) error {
-	_, err := q.db.Exec(ctx, setCampaignPicture, arg.PictureID, arg.CampaignID)
+func (q *Queries) SoftDeleteCampaignByID(ctx context.Context, campaignID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, softDeleteCampaignByID, campaignID)
 	return err
 }
 
@@ -374,10 +524,16 @@ SET
     impressions_limit = $1::bigint, clicks_limit = $2::bigint,
     cost_per_impression = $3::decimal(10,2), cost_per_click = $4::decimal(10,2),
     ad_title = $5::varchar, ad_text = $6::varchar,
-    start_date = $7::int, end_date = $8::int
+    start_date = $7::int, end_date = $8::int,
+    frequency_cap_total = COALESCE($9::int, frequency_cap_total),
+    frequency_cap_daily = COALESCE($10::int, frequency_cap_daily),
+    budget_total = COALESCE($11::decimal(10,2), budget_total),
+    budget_daily = COALESCE($12::decimal(10,2), budget_daily),
+    pacing = COALESCE($13::varchar, pacing),
+    audience_id = $14::uuid
 WHERE
-    id = $9::uuid
-RETURNING id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id
+    id = $15::uuid
+RETURNING id, advertiser_id, impressions_limit, clicks_limit, cost_per_impression, cost_per_click, ad_title, ad_text, start_date, end_date, pic_id, frequency_cap_total, frequency_cap_daily, budget_total, budget_daily, pacing, status, deleted_at, audience_id
 `
 
 type UpdateCampaignParams struct {
@@ -389,6 +545,12 @@ type UpdateCampaignParams struct {
 	AdText            string
 	StartDate         int32
 	EndDate           int32
+	FrequencyCapTotal pgtype.Int4
+	FrequencyCapDaily pgtype.Int4
+	BudgetTotal       pgtype.Numeric
+	BudgetDaily       pgtype.Numeric
+	Pacing            pgtype.Text
+	AudienceID        pgtype.UUID
 	CampaignID        uuid.UUID
 }
 
@@ -402,6 +564,12 @@ func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams)
 		arg.AdText,
 		arg.StartDate,
 		arg.EndDate,
+		arg.FrequencyCapTotal,
+		arg.FrequencyCapDaily,
+		arg.BudgetTotal,
+		arg.BudgetDaily,
+		arg.Pacing,
+		arg.AudienceID,
 		arg.CampaignID,
 	)
 	var i Campaign
@@ -417,6 +585,14 @@ func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams)
 		&i.StartDate,
 		&i.EndDate,
 		&i.PicID,
+		&i.FrequencyCapTotal,
+		&i.FrequencyCapDaily,
+		&i.BudgetTotal,
+		&i.BudgetDaily,
+		&i.Pacing,
+		&i.Status,
+		&i.DeletedAt,
+		&i.AudienceID,
 	)
 	return i, err
 }
@@ -426,18 +602,28 @@ UPDATE campaigns_targeting
 SET
     gender = COALESCE($1::varchar, NULL),
     age_from = COALESCE($2::int, NULL), age_to = COALESCE($3::int, NULL),
-    location = COALESCE($4::varchar, NULL)
+    location = COALESCE($4::varchar, NULL),
+    genders = COALESCE($5::text[], '{}'), locations = COALESCE($6::text[], '{}'),
+    excluded_locations = COALESCE($7::text[], '{}'),
+    interests = COALESCE($8::text[], '{}'), interests_mode = COALESCE($9::varchar, NULL),
+    rules = $10::jsonb
 WHERE
-    campaign_id = $5::uuid
-RETURNING id, campaign_id, gender, age_from, age_to, location
+    campaign_id = $11::uuid
+RETURNING id, campaign_id, gender, age_from, age_to, location, genders, locations, excluded_locations, interests, interests_mode, rules
 `
 
 type UpdateCampaignTargetingParams struct {
-	Gender     pgtype.Text
-	AgeFrom    pgtype.Int4
-	AgeTo      pgtype.Int4
-	Location   pgtype.Text
-	CampaignID uuid.UUID
+	Gender            pgtype.Text
+	AgeFrom           pgtype.Int4
+	AgeTo             pgtype.Int4
+	Location          pgtype.Text
+	Genders           []string
+	Locations         []string
+	ExcludedLocations []string
+	Interests         []string
+	InterestsMode     pgtype.Text
+	Rules             []byte
+	CampaignID        uuid.UUID
 }
 
 func (q *Queries) UpdateCampaignTargeting(ctx context.Context, arg UpdateCampaignTargetingParams) (CampaignsTargeting, error) {
@@ -446,6 +632,12 @@ func (q *Queries) UpdateCampaignTargeting(ctx context.Context, arg UpdateCampaig
 		arg.AgeFrom,
 		arg.AgeTo,
 		arg.Location,
+		arg.Genders,
+		arg.Locations,
+		arg.ExcludedLocations,
+		arg.Interests,
+		arg.InterestsMode,
+		arg.Rules,
 		arg.CampaignID,
 	)
 	var i CampaignsTargeting
@@ -456,6 +648,12 @@ func (q *Queries) UpdateCampaignTargeting(ctx context.Context, arg UpdateCampaig
 		&i.AgeFrom,
 		&i.AgeTo,
 		&i.Location,
+		&i.Genders,
+		&i.Locations,
+		&i.ExcludedLocations,
+		&i.Interests,
+		&i.InterestsMode,
+		&i.Rules,
 	)
 	return i, err
 }
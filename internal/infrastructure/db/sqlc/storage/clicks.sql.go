@@ -13,25 +13,76 @@ import (
 
 const createClick = `-- name: CreateClick :one
 INSERT INTO clicks (
-    campaign_id, client_id
-) VALUES (
-    $1::uuid, $2::uuid
+    campaign_id, client_id, date, cost, capped
 )
-RETURNING id, campaign_id, client_id
+SELECT
+    campaigns.id, $1::uuid, $2::int,
+    CASE
+        WHEN billed_clicks.count >= campaigns.clicks_limit THEN 0.00
+        ELSE campaigns.cost_per_click
+    END,
+    billed_clicks.count >= campaigns.clicks_limit
+FROM campaigns
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS count FROM clicks
+    WHERE clicks.campaign_id = campaigns.id AND NOT clicks.capped
+) AS billed_clicks
+WHERE campaigns.id = $3::uuid
+RETURNING id, campaign_id, client_id, date, cost, capped
 `
 
 type CreateClickParams struct {
-	CampaignID uuid.UUID
 	ClientID   uuid.UUID
+	Date       int32
+	CampaignID uuid.UUID
 }
 
+// Clicks over the campaign clicks_limit are recorded as capped and are not billed
 func (q *Queries) CreateClick(ctx context.Context, arg CreateClickParams) (Click, error) {
-	row := q.db.QueryRow(ctx, createClick, arg.CampaignID, arg.ClientID)
+	row := q.db.QueryRow(ctx, createClick, arg.ClientID, arg.Date, arg.CampaignID)
 	var i Click
-	err := row.Scan(&i.ID, &i.CampaignID, &i.ClientID)
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.ClientID,
+		&i.Date,
+		&i.Cost,
+		&i.Capped,
+	)
 	return i, err
 }
 
+const getBilledClicksCounters = `-- name: GetBilledClicksCounters :many
+SELECT campaign_id, COUNT(*)::bigint AS count FROM clicks
+WHERE NOT capped
+GROUP BY campaign_id
+`
+
+type GetBilledClicksCountersRow struct {
+	CampaignID uuid.UUID
+	Count      int64
+}
+
+func (q *Queries) GetBilledClicksCounters(ctx context.Context) ([]GetBilledClicksCountersRow, error) {
+	rows, err := q.db.Query(ctx, getBilledClicksCounters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBilledClicksCountersRow
+	for rows.Next() {
+		var i GetBilledClicksCountersRow
+		if err := rows.Scan(&i.CampaignID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const isClicked = `-- name: IsClicked :one
 SELECT 1 FROM clicks
 WHERE
@@ -50,3 +101,15 @@ func (q *Queries) IsClicked(ctx context.Context, arg IsClickedParams) (int32, er
 	err := row.Scan(&column_1)
 	return column_1, err
 }
+
+const setClickUnbilled = `-- name: SetClickUnbilled :exec
+UPDATE clicks
+SET cost = 0.00, capped = TRUE
+WHERE id = $1::uuid
+`
+
+// Click the advertiser can't pay for is recorded as capped and is not billed
+func (q *Queries) SetClickUnbilled(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, setClickUnbilled, id)
+	return err
+}
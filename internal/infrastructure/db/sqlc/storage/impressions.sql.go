@@ -13,21 +13,67 @@ import (
 
 const createImpression = `-- name: CreateImpression :one
 INSERT INTO impressions (
-    campaign_id, client_id
-) VALUES (
-    $1::uuid, $2::uuid
+    campaign_id, client_id, date, cost
 )
-RETURNING id, campaign_id, client_id
+SELECT
+    campaigns.id, $1::uuid, $2::int, campaigns.cost_per_impression
+FROM campaigns
+WHERE campaigns.id = $3::uuid
+RETURNING id, campaign_id, client_id, date, cost
 `
 
 type CreateImpressionParams struct {
-	CampaignID uuid.UUID
 	ClientID   uuid.UUID
+	Date       int32
+	CampaignID uuid.UUID
 }
 
 func (q *Queries) CreateImpression(ctx context.Context, arg CreateImpressionParams) (Impression, error) {
-	row := q.db.QueryRow(ctx, createImpression, arg.CampaignID, arg.ClientID)
+	row := q.db.QueryRow(ctx, createImpression, arg.ClientID, arg.Date, arg.CampaignID)
 	var i Impression
-	err := row.Scan(&i.ID, &i.CampaignID, &i.ClientID)
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.ClientID,
+		&i.Date,
+		&i.Cost,
+	)
 	return i, err
 }
+
+const getImpressionsCounters = `-- name: GetImpressionsCounters :many
+SELECT campaign_id, client_id, date, COUNT(*)::bigint AS count FROM impressions
+GROUP BY campaign_id, client_id, date
+`
+
+type GetImpressionsCountersRow struct {
+	CampaignID uuid.UUID
+	ClientID   uuid.UUID
+	Date       int32
+	Count      int64
+}
+
+func (q *Queries) GetImpressionsCounters(ctx context.Context) ([]GetImpressionsCountersRow, error) {
+	rows, err := q.db.Query(ctx, getImpressionsCounters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetImpressionsCountersRow
+	for rows.Next() {
+		var i GetImpressionsCountersRow
+		if err := rows.Scan(
+			&i.CampaignID,
+			&i.ClientID,
+			&i.Date,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
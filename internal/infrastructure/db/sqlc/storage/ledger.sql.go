@@ -0,0 +1,178 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: ledger.sql
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const chargeCampaignAdvertiser = `-- name: ChargeCampaignAdvertiser :one
+WITH charged AS (
+    UPDATE advertisers
+    SET balance = balance - $4::decimal(12,2)
+    FROM campaigns
+    WHERE
+        campaigns.id = $1::uuid AND advertisers.id = campaigns.advertiser_id AND
+        (NOT $5::boolean OR advertisers.balance >= $4::decimal(12,2))
+    RETURNING advertisers.id
+)
+INSERT INTO ledger (
+    advertiser_id, campaign_id, operation, amount, date
+)
+SELECT charged.id, $1::uuid, $2::varchar, -@amount::decimal(12,2), $3::int
+FROM charged
+RETURNING id, advertiser_id, campaign_id, operation, amount, date, created_at
+`
+
+type ChargeCampaignAdvertiserParams struct {
+	CampaignID     uuid.UUID
+	Operation      string
+	Date           int32
+	Amount         pgtype.Numeric
+	EnforceBalance bool
+}
+
+// Withdraws the amount from the balance of the campaign advertiser and records it in the ledger.
+// If the balance is enforced, nothing is charged when the balance doesn't cover the amount
+func (q *Queries) ChargeCampaignAdvertiser(ctx context.Context, arg ChargeCampaignAdvertiserParams) (Ledger, error) {
+	row := q.db.QueryRow(ctx, chargeCampaignAdvertiser,
+		arg.CampaignID,
+		arg.Operation,
+		arg.Date,
+		arg.Amount,
+		arg.EnforceBalance,
+	)
+	var i Ledger
+	err := row.Scan(
+		&i.ID,
+		&i.AdvertiserID,
+		&i.CampaignID,
+		&i.Operation,
+		&i.Amount,
+		&i.Date,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createLedgerEntry = `-- name: CreateLedgerEntry :exec
+INSERT INTO ledger (
+    advertiser_id, campaign_id, operation, amount, date
+) VALUES (
+    $1::uuid, $2::uuid, $3::varchar, $4::decimal(12,2), $5::int
+)
+`
+
+type CreateLedgerEntryParams struct {
+	AdvertiserID uuid.UUID
+	CampaignID   pgtype.UUID
+	Operation    string
+	Amount       pgtype.Numeric
+	Date         int32
+}
+
+func (q *Queries) CreateLedgerEntry(ctx context.Context, arg CreateLedgerEntryParams) error {
+	_, err := q.db.Exec(ctx, createLedgerEntry,
+		arg.AdvertiserID,
+		arg.CampaignID,
+		arg.Operation,
+		arg.Amount,
+		arg.Date,
+	)
+	return err
+}
+
+const getAdvertisersBalances = `-- name: GetAdvertisersBalances :many
+SELECT id, balance FROM advertisers
+`
+
+type GetAdvertisersBalancesRow struct {
+	ID      uuid.UUID
+	Balance pgtype.Numeric
+}
+
+func (q *Queries) GetAdvertisersBalances(ctx context.Context) ([]GetAdvertisersBalancesRow, error) {
+	rows, err := q.db.Query(ctx, getAdvertisersBalances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAdvertisersBalancesRow
+	for rows.Next() {
+		var i GetAdvertisersBalancesRow
+		if err := rows.Scan(&i.ID, &i.Balance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLedgerByAdvertiserID = `-- name: GetLedgerByAdvertiserID :many
+SELECT id, advertiser_id, campaign_id, operation, amount, date, created_at FROM ledger
+WHERE advertiser_id = $3::uuid
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetLedgerByAdvertiserIDParams struct {
+	Limit        int32
+	Offset       int32
+	AdvertiserID uuid.UUID
+}
+
+func (q *Queries) GetLedgerByAdvertiserID(ctx context.Context, arg GetLedgerByAdvertiserIDParams) ([]Ledger, error) {
+	rows, err := q.db.Query(ctx, getLedgerByAdvertiserID, arg.Limit, arg.Offset, arg.AdvertiserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ledger
+	for rows.Next() {
+		var i Ledger
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdvertiserID,
+			&i.CampaignID,
+			&i.Operation,
+			&i.Amount,
+			&i.Date,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const topUpAdvertiserBalance = `-- name: TopUpAdvertiserBalance :one
+UPDATE advertisers
+SET balance = balance + $1::decimal(12,2)
+WHERE id = $2::uuid
+RETURNING id, name, balance
+`
+
+type TopUpAdvertiserBalanceParams struct {
+	Amount       pgtype.Numeric
+	AdvertiserID uuid.UUID
+}
+
+func (q *Queries) TopUpAdvertiserBalance(ctx context.Context, arg TopUpAdvertiserBalanceParams) (Advertiser, error) {
+	row := q.db.QueryRow(ctx, topUpAdvertiserBalance, arg.Amount, arg.AdvertiserID)
+	var i Advertiser
+	err := row.Scan(&i.ID, &i.Name, &i.Balance)
+	return i, err
+}
@@ -10,8 +10,22 @@ import (
 )
 
 type Advertiser struct {
-	ID   uuid.UUID
-	Name string
+	ID      uuid.UUID
+	Name    string
+	Balance pgtype.Numeric
+}
+
+type Audience struct {
+	ID           uuid.UUID
+	AdvertiserID uuid.UUID
+	Name         string
+	Targeting    []byte
+	CreatedAt    pgtype.Timestamp
+}
+
+type AudienceClient struct {
+	AudienceID uuid.UUID
+	ClientID   uuid.UUID
 }
 
 type Campaign struct {
@@ -26,27 +40,64 @@ type Campaign struct {
 	StartDate         int32
 	EndDate           int32
 	PicID             pgtype.Text
+	FrequencyCapTotal int32
+	FrequencyCapDaily int32
+	BudgetTotal       pgtype.Numeric
+	BudgetDaily       pgtype.Numeric
+	Pacing            string
+	Status            string
+	DeletedAt         pgtype.Timestamp
+	AudienceID        pgtype.UUID
 }
 
-type CampaignsTargeting struct {
-	ID         uuid.UUID
+type CampaignVersion struct {
 	CampaignID uuid.UUID
-	Gender     pgtype.Text
-	AgeFrom    pgtype.Int4
-	AgeTo      pgtype.Int4
-	Location   pgtype.Text
+	Version    int32
+	Date       int32
+	Snapshot   []byte
+	CreatedAt  pgtype.Timestamp
+}
+
+type CampaignsTargeting struct {
+	ID                uuid.UUID
+	CampaignID        uuid.UUID
+	Gender            pgtype.Text
+	AgeFrom           pgtype.Int4
+	AgeTo             pgtype.Int4
+	Location          pgtype.Text
+	Genders           []string
+	Locations         []string
+	ExcludedLocations []string
+	Interests         []string
+	InterestsMode     pgtype.Text
+	Rules             []byte
 }
 
 type Click struct {
 	ID         uuid.UUID
 	CampaignID uuid.UUID
 	ClientID   uuid.UUID
+	Date       int32
+	Cost       pgtype.Numeric
+	Capped     bool
 }
 
 type Impression struct {
 	ID         uuid.UUID
 	CampaignID uuid.UUID
 	ClientID   uuid.UUID
+	Date       int32
+	Cost       pgtype.Numeric
+}
+
+type Ledger struct {
+	ID           uuid.UUID
+	AdvertiserID uuid.UUID
+	CampaignID   pgtype.UUID
+	Operation    string
+	Amount       pgtype.Numeric
+	Date         int32
+	CreatedAt    pgtype.Timestamp
 }
 
 type MlScore struct {
@@ -56,9 +107,10 @@ type MlScore struct {
 }
 
 type User struct {
-	ID       uuid.UUID
-	Login    string
-	Age      int32
-	Location string
-	Gender   string
+	ID        uuid.UUID
+	Login     string
+	Age       int32
+	Location  string
+	Gender    string
+	Interests []string
 }
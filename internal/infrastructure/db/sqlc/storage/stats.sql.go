@@ -0,0 +1,289 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: stats.sql
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getAdvertiserDailyStats = `-- name: GetAdvertiserDailyStats :many
+SELECT
+    days.date::int AS date,
+    COALESCE(daily_impressions.impressions_count, 0)::bigint AS impressions_count,
+    COALESCE(daily_clicks.clicks_count, 0)::bigint AS clicks_count,
+    COALESCE(daily_clicks.capped_clicks_count, 0)::bigint AS capped_clicks_count,
+    COALESCE(daily_impressions.spent_impressions, 0)::decimal(12,2) AS spent_impressions,
+    COALESCE(daily_clicks.spent_clicks, 0)::decimal(12,2) AS spent_clicks
+FROM generate_series(
+    (SELECT MIN(start_date) FROM campaigns WHERE campaigns.advertiser_id = $1::uuid),
+    LEAST(
+        (SELECT MAX(end_date) FROM campaigns WHERE campaigns.advertiser_id = $1::uuid),
+        $2::int
+    )
+) AS days(date)
+LEFT JOIN (
+    SELECT impressions.date, COUNT(*) AS impressions_count, SUM(impressions.cost) AS spent_impressions
+    FROM impressions
+    JOIN campaigns ON campaigns.id = impressions.campaign_id
+    WHERE campaigns.advertiser_id = $1::uuid
+    GROUP BY impressions.date
+) AS daily_impressions ON daily_impressions.date = days.date
+LEFT JOIN (
+    SELECT
+        clicks.date, COUNT(*) AS clicks_count,
+        COUNT(*) FILTER (WHERE clicks.capped) AS capped_clicks_count,
+        SUM(clicks.cost) AS spent_clicks
+    FROM clicks
+    JOIN campaigns ON campaigns.id = clicks.campaign_id
+    WHERE campaigns.advertiser_id = $1::uuid
+    GROUP BY clicks.date
+) AS daily_clicks ON daily_clicks.date = days.date
+ORDER BY days.date
+`
+
+type GetAdvertiserDailyStatsParams struct {
+	AdvertiserID uuid.UUID
+	CurDate      int32
+}
+
+type GetAdvertiserDailyStatsRow struct {
+	Date              int32
+	ImpressionsCount  int64
+	ClicksCount       int64
+	CappedClicksCount int64
+	SpentImpressions  pgtype.Numeric
+	SpentClicks       pgtype.Numeric
+}
+
+func (q *Queries) GetAdvertiserDailyStats(ctx context.Context, arg GetAdvertiserDailyStatsParams) ([]GetAdvertiserDailyStatsRow, error) {
+	rows, err := q.db.Query(ctx, getAdvertiserDailyStats, arg.AdvertiserID, arg.CurDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAdvertiserDailyStatsRow
+	for rows.Next() {
+		var i GetAdvertiserDailyStatsRow
+		if err := rows.Scan(
+			&i.Date,
+			&i.ImpressionsCount,
+			&i.ClicksCount,
+			&i.CappedClicksCount,
+			&i.SpentImpressions,
+			&i.SpentClicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCampaignDailyStats = `-- name: GetCampaignDailyStats :many
+SELECT
+    days.date::int AS date,
+    COALESCE(daily_impressions.impressions_count, 0)::bigint AS impressions_count,
+    COALESCE(daily_clicks.clicks_count, 0)::bigint AS clicks_count,
+    COALESCE(daily_clicks.capped_clicks_count, 0)::bigint AS capped_clicks_count,
+    COALESCE(daily_impressions.spent_impressions, 0)::decimal(12,2) AS spent_impressions,
+    COALESCE(daily_clicks.spent_clicks, 0)::decimal(12,2) AS spent_clicks
+FROM campaigns
+CROSS JOIN LATERAL generate_series(
+    campaigns.start_date,
+    LEAST(campaigns.end_date, $1::int)
+) AS days(date)
+LEFT JOIN (
+    SELECT impressions.date, COUNT(*) AS impressions_count, SUM(impressions.cost) AS spent_impressions
+    FROM impressions
+    WHERE impressions.campaign_id = $2::uuid
+    GROUP BY impressions.date
+) AS daily_impressions ON daily_impressions.date = days.date
+LEFT JOIN (
+    SELECT
+        clicks.date, COUNT(*) AS clicks_count,
+        COUNT(*) FILTER (WHERE clicks.capped) AS capped_clicks_count,
+        SUM(clicks.cost) AS spent_clicks
+    FROM clicks
+    WHERE clicks.campaign_id = $2::uuid
+    GROUP BY clicks.date
+) AS daily_clicks ON daily_clicks.date = days.date
+WHERE campaigns.id = $2::uuid
+ORDER BY days.date
+`
+
+type GetCampaignDailyStatsParams struct {
+	CurDate    int32
+	CampaignID uuid.UUID
+}
+
+type GetCampaignDailyStatsRow struct {
+	Date              int32
+	ImpressionsCount  int64
+	ClicksCount       int64
+	CappedClicksCount int64
+	SpentImpressions  pgtype.Numeric
+	SpentClicks       pgtype.Numeric
+}
+
+func (q *Queries) GetCampaignDailyStats(ctx context.Context, arg GetCampaignDailyStatsParams) ([]GetCampaignDailyStatsRow, error) {
+	rows, err := q.db.Query(ctx, getCampaignDailyStats, arg.CurDate, arg.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCampaignDailyStatsRow
+	for rows.Next() {
+		var i GetCampaignDailyStatsRow
+		if err := rows.Scan(
+			&i.Date,
+			&i.ImpressionsCount,
+			&i.ClicksCount,
+			&i.CappedClicksCount,
+			&i.SpentImpressions,
+			&i.SpentClicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCampaignSpent = `-- name: GetCampaignSpent :one
+SELECT
+    (
+        (SELECT COALESCE(SUM(impressions.cost), 0) FROM impressions WHERE impressions.campaign_id = $1::uuid) +
+        (SELECT COALESCE(SUM(clicks.cost), 0) FROM clicks WHERE clicks.campaign_id = $1::uuid)
+    )::decimal(12,2) AS spent_total,
+    (
+        (
+            SELECT COALESCE(SUM(impressions.cost), 0) FROM impressions
+            WHERE impressions.campaign_id = $1::uuid AND impressions.date = $2::int
+        ) +
+        (
+            SELECT COALESCE(SUM(clicks.cost), 0) FROM clicks
+            WHERE clicks.campaign_id = $1::uuid AND clicks.date = $2::int
+        )
+    )::decimal(12,2) AS spent_daily
+`
+
+type GetCampaignSpentParams struct {
+	CampaignID uuid.UUID
+	CurDate    int32
+}
+
+type GetCampaignSpentRow struct {
+	SpentTotal pgtype.Numeric
+	SpentDaily pgtype.Numeric
+}
+
+func (q *Queries) GetCampaignSpent(ctx context.Context, arg GetCampaignSpentParams) (GetCampaignSpentRow, error) {
+	row := q.db.QueryRow(ctx, getCampaignSpent, arg.CampaignID, arg.CurDate)
+	var i GetCampaignSpentRow
+	err := row.Scan(&i.SpentTotal, &i.SpentDaily)
+	return i, err
+}
+
+const getCampaignStats = `-- name: GetCampaignStats :one
+SELECT
+    (
+        SELECT COUNT(*) FROM impressions
+        WHERE impressions.campaign_id = campaigns.id
+    )::bigint AS impressions_count,
+    GREATEST(
+        (
+            SELECT COUNT(*) FROM impressions
+            WHERE impressions.campaign_id = campaigns.id
+        ) - campaigns.impressions_limit,
+        0
+    )::bigint AS impressions_overshoot,
+    (
+        SELECT COUNT(*) FROM clicks
+        WHERE clicks.campaign_id = campaigns.id
+    )::bigint AS clicks_count,
+    (
+        SELECT COUNT(*) FROM clicks
+        WHERE clicks.campaign_id = campaigns.id AND clicks.capped
+    )::bigint AS capped_clicks_count,
+    (
+        SELECT COALESCE(SUM(impressions.cost), 0) FROM impressions
+        WHERE impressions.campaign_id = campaigns.id
+    )::decimal(12,2) AS spent_impressions,
+    (
+        SELECT COALESCE(SUM(clicks.cost), 0) FROM clicks
+        WHERE clicks.campaign_id = campaigns.id
+    )::decimal(12,2) AS spent_clicks
+FROM campaigns
+WHERE campaigns.id = $1::uuid
+`
+
+type GetCampaignStatsRow struct {
+	ImpressionsCount     int64
+	ImpressionsOvershoot int64
+	ClicksCount          int64
+	CappedClicksCount    int64
+	SpentImpressions     pgtype.Numeric
+	SpentClicks          pgtype.Numeric
+}
+
+func (q *Queries) GetCampaignStats(ctx context.Context, campaignID uuid.UUID) (GetCampaignStatsRow, error) {
+	row := q.db.QueryRow(ctx, getCampaignStats, campaignID)
+	var i GetCampaignStatsRow
+	err := row.Scan(
+		&i.ImpressionsCount,
+		&i.ImpressionsOvershoot,
+		&i.ClicksCount,
+		&i.CappedClicksCount,
+		&i.SpentImpressions,
+		&i.SpentClicks,
+	)
+	return i, err
+}
+
+const getSpentCounters = `-- name: GetSpentCounters :many
+SELECT events.campaign_id, events.date, COALESCE(SUM(events.cost), 0)::decimal(12,2) AS spent
+FROM (
+    SELECT impressions.campaign_id, impressions.date, impressions.cost FROM impressions
+    UNION ALL
+    SELECT clicks.campaign_id, clicks.date, clicks.cost FROM clicks
+) AS events
+GROUP BY events.campaign_id, events.date
+`
+
+type GetSpentCountersRow struct {
+	CampaignID uuid.UUID
+	Date       int32
+	Spent      pgtype.Numeric
+}
+
+func (q *Queries) GetSpentCounters(ctx context.Context) ([]GetSpentCountersRow, error) {
+	rows, err := q.db.Query(ctx, getSpentCounters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSpentCountersRow
+	for rows.Next() {
+		var i GetSpentCountersRow
+		if err := rows.Scan(&i.CampaignID, &i.Date, &i.Spent); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -9,24 +9,27 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
-    id, login, age, location, gender
+    id, login, age, location, gender, interests
 ) VALUES (
     $1::uuid, $2::varchar,
-    $3::integer, $4::varchar, $5::varchar
+    $3::integer, $4::varchar, $5::varchar,
+    COALESCE($6::text[], '{}')
 )
-RETURNING id, login, age, location, gender
+RETURNING id, login, age, location, gender, interests
 `
 
 type CreateUserParams struct {
-	ID       uuid.UUID
-	Login    string
-	Age      int32
-	Location string
-	Gender   string
+	ID        uuid.UUID
+	Login     string
+	Age       int32
+	Location  string
+	Gender    string
+	Interests []string
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -36,6 +39,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Age,
 		arg.Location,
 		arg.Gender,
+		arg.Interests,
 	)
 	var i User
 	err := row.Scan(
@@ -44,12 +48,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Age,
 		&i.Location,
 		&i.Gender,
+		&i.Interests,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, login, age, location, gender FROM users
+SELECT id, login, age, location, gender, interests FROM users
 WHERE id = $1::uuid
 `
 
@@ -62,26 +67,90 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Age,
 		&i.Location,
 		&i.Gender,
+		&i.Interests,
 	)
 	return i, err
 }
 
+const getUsersByFilter = `-- name: GetUsersByFilter :many
+SELECT id, login, age, location, gender, interests FROM users
+WHERE
+($1::int IS NULL OR age >= $1::int) AND
+($2::int IS NULL OR age <= $2::int) AND
+($3::varchar[] IS NULL OR gender = ANY($3::varchar[])) AND
+($4::varchar[] IS NULL OR location = ANY($4::varchar[])) AND
+($5::varchar[] IS NULL OR location <> ALL($5::varchar[])) AND
+($6::text[] IS NULL OR interests && $6::text[]) AND
+($7::text[] IS NULL OR interests @> $7::text[]) AND
+($8::uuid[] IS NULL OR id = ANY($8::uuid[]))
+`
+
+type GetUsersByFilterParams struct {
+	AgeFrom           pgtype.Int4
+	AgeTo             pgtype.Int4
+	Genders           []string
+	Locations         []string
+	ExcludedLocations []string
+	InterestsAny      []string
+	InterestsAll      []string
+	ClientIds         []uuid.UUID
+}
+
+// Filters clients for audience estimation, NULL filters match everyone
+func (q *Queries) GetUsersByFilter(ctx context.Context, arg GetUsersByFilterParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByFilter,
+		arg.AgeFrom,
+		arg.AgeTo,
+		arg.Genders,
+		arg.Locations,
+		arg.ExcludedLocations,
+		arg.InterestsAny,
+		arg.InterestsAll,
+		arg.ClientIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Login,
+			&i.Age,
+			&i.Location,
+			&i.Gender,
+			&i.Interests,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
 SET
 login = $1::varchar,
 age = $2::int,
 location = $3::varchar,
-gender = $4::varchar
-WHERE id = $5::uuid
+gender = $4::varchar,
+interests = COALESCE($5::text[], interests)
+WHERE id = $6::uuid
 `
 
 type UpdateUserParams struct {
-	Login    string
-	Age      int32
-	Location string
-	Gender   string
-	ID       uuid.UUID
+	Login     string
+	Age       int32
+	Location  string
+	Gender    string
+	Interests []string
+	ID        uuid.UUID
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
@@ -90,6 +159,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 		arg.Age,
 		arg.Location,
 		arg.Gender,
+		arg.Interests,
 		arg.ID,
 	)
 	return err
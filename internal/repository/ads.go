@@ -5,64 +5,189 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/infrastructure/db/sqlc/storage"
 )
 
 type AdsRepository struct {
 	queries *storage.Queries
+	dbConn  *pgxpool.Pool
 }
 
-func NewAdsRepository(queries *storage.Queries) *AdsRepository {
+func NewAdsRepository(queries *storage.Queries, dbConn *pgxpool.Pool) *AdsRepository {
 	return &AdsRepository{
 		queries: queries,
+		dbConn:  dbConn,
 	}
 }
 
-func (r *AdsRepository) GetRelativeAd(ctx context.Context, clientId uuid.UUID, currentDate int32) (*domain.UserAd, error) {
-	client, err := r.queries.GetUserByID(ctx, clientId)
+// Impression records the impression and charges the advertiser in one transaction
+func (r *AdsRepository) Impression(ctx context.Context, adId, clientId uuid.UUID, currentDate int32, enforceBalance bool) error {
+	tx, err := r.dbConn.Begin(ctx)
 	if err != nil {
-		return nil, err
-	}
-	ad, err := r.queries.GetRelativeAd(ctx, storage.GetRelativeAdParams{
-		ClientID: clientId,
-		Gender:   client.Gender,
-		Age:      client.Age,
-		Location: client.Location,
-		CurDate:  currentDate,
-	})
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return &domain.UserAd{
-		AdId:         ad.ID,
-		AdTitle:      ad.AdTitle,
-		AdText:       ad.AdText,
-		AdvertiserID: ad.AdvertiserID,
-	}, nil
-}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
 
-func (r *AdsRepository) Impression(ctx context.Context, adId, clientId uuid.UUID) error {
-	_, err := r.queries.CreateImpression(ctx, storage.CreateImpressionParams{
+	impression, err := qtx.CreateImpression(ctx, storage.CreateImpressionParams{
 		CampaignID: adId,
 		ClientID:   clientId,
+		Date:       currentDate,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	charged, err := chargeAdvertiser(ctx, qtx, adId, impression.Cost, domain.LedgerImpression, currentDate, enforceBalance)
+	if err != nil {
+		return err
+	}
+	if !charged {
+		return domain.ErrInsufficientBalance
+	}
+	return tx.Commit(ctx)
 }
 
-func (r *AdsRepository) Click(ctx context.Context, adId, clientId uuid.UUID) error {
+// Click records the click of the client and returns its cost and true if the click is billed.
+// If the balance is enforced and doesn't cover the click, the click is recorded as not billed
+func (r *AdsRepository) Click(ctx context.Context, adId, clientId uuid.UUID, currentDate int32, enforceBalance bool) (float64, bool, error) {
 	isClicked, err := r.queries.IsClicked(ctx, storage.IsClickedParams{
 		CampaignID: adId,
 		ClientID:   clientId,
 	})
 	if isClicked == 1 {
-		return nil
+		return 0, false, nil
 	} else if err != nil && err != pgx.ErrNoRows {
-		return err
+		return 0, false, err
+	}
+
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return 0, false, err
 	}
-	_, err = r.queries.CreateClick(ctx, storage.CreateClickParams{
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	click, err := qtx.CreateClick(ctx, storage.CreateClickParams{
 		CampaignID: adId,
 		ClientID:   clientId,
+		Date:       currentDate,
 	})
-	return err
+	if err != nil {
+		return 0, false, err
+	}
+
+	charged, err := chargeAdvertiser(ctx, qtx, adId, click.Cost, domain.LedgerClick, currentDate, enforceBalance)
+	if err != nil {
+		return 0, false, err
+	}
+	if !charged {
+		if err := qtx.SetClickUnbilled(ctx, click.ID); err != nil {
+			return 0, false, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return 0, false, err
+		}
+		return 0, false, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, err
+	}
+
+	cost, err := convertNumericToFloat(click.Cost)
+	if err != nil {
+		return 0, false, err
+	}
+	return cost, !click.Capped, nil
+}
+
+func (r *AdsRepository) GetImpressionsCounters(ctx context.Context) ([]domain.ImpressionsCounter, error) {
+	countersDB, err := r.queries.GetImpressionsCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make([]domain.ImpressionsCounter, len(countersDB))
+	for i, counterDB := range countersDB {
+		counters[i] = domain.ImpressionsCounter{
+			CampaignID: counterDB.CampaignID,
+			ClientID:   counterDB.ClientID,
+			Date:       counterDB.Date,
+			Count:      counterDB.Count,
+		}
+	}
+	return counters, nil
+}
+
+func (r *AdsRepository) GetBilledClicksCounters(ctx context.Context) ([]domain.ClicksCounter, error) {
+	countersDB, err := r.queries.GetBilledClicksCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make([]domain.ClicksCounter, len(countersDB))
+	for i, counterDB := range countersDB {
+		counters[i] = domain.ClicksCounter{
+			CampaignID: counterDB.CampaignID,
+			Count:      counterDB.Count,
+		}
+	}
+	return counters, nil
+}
+
+func (r *AdsRepository) GetSpentCounters(ctx context.Context) ([]domain.SpentCounter, error) {
+	countersDB, err := r.queries.GetSpentCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make([]domain.SpentCounter, len(countersDB))
+	for i, counterDB := range countersDB {
+		amount, err := convertNumericToFloat(counterDB.Spent)
+		if err != nil {
+			return nil, err
+		}
+		counters[i] = domain.SpentCounter{
+			CampaignID: counterDB.CampaignID,
+			Date:       counterDB.Date,
+			Amount:     amount,
+		}
+	}
+	return counters, nil
+}
+
+// chargeAdvertiser withdraws the cost of the event from the campaign advertiser balance.
+// Free events (e.g. capped clicks) are not recorded in the ledger.
+// Returns false if the balance is enforced and doesn't cover the cost
+func chargeAdvertiser(ctx context.Context,
+	qtx *storage.Queries,
+	campaignID uuid.UUID,
+	cost pgtype.Numeric,
+	operation string,
+	currentDate int32,
+	enforceBalance bool) (bool, error) {
+	costFloat, err := convertNumericToFloat(cost)
+	if err != nil {
+		return false, err
+	}
+	if costFloat == 0 {
+		return true, nil
+	}
+
+	_, err = qtx.ChargeCampaignAdvertiser(ctx, storage.ChargeCampaignAdvertiserParams{
+		CampaignID:     campaignID,
+		Operation:      operation,
+		Date:           currentDate,
+		Amount:         cost,
+		EnforceBalance: enforceBalance,
+	})
+	if err == pgx.ErrNoRows && enforceBalance {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
 }
@@ -5,24 +5,28 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/infrastructure/db/sqlc/storage"
 )
 
 type AdvertiserRepository struct {
 	queries *storage.Queries
+	dbConn  *pgxpool.Pool
 }
 
-func NewAdvertiserRepository(queries *storage.Queries) *AdvertiserRepository {
+func NewAdvertiserRepository(queries *storage.Queries, dbConn *pgxpool.Pool) *AdvertiserRepository {
 	return &AdvertiserRepository{
 		queries: queries,
+		dbConn:  dbConn,
 	}
 }
 
 func (r *AdvertiserRepository) CreateUpdateAdvertisers(ctx context.Context, advertisers []*domain.Advertiser) ([]*domain.Advertiser, error) {
 	for _, advertiser := range advertisers {
-		_, err := r.queries.GetAdvertiserByID(ctx, advertiser.ID)
+		existingAdvertiser, err := r.queries.GetAdvertiserByID(ctx, advertiser.ID)
 		if err == pgx.ErrNoRows {
+			advertiser.Balance = 0
 			err = r.queries.CreateAdvertiser(ctx, storage.CreateAdvertiserParams{
 				ID:   advertiser.ID,
 				Name: advertiser.Name,
@@ -38,6 +42,11 @@ func (r *AdvertiserRepository) CreateUpdateAdvertisers(ctx context.Context, adve
 			if err != nil {
 				return []*domain.Advertiser{}, err
 			}
+			// Balance is changed only with top-ups
+			advertiser.Balance, err = convertNumericToFloat(existingAdvertiser.Balance)
+			if err != nil {
+				return []*domain.Advertiser{}, err
+			}
 		}
 	}
 
@@ -51,10 +60,99 @@ func (r *AdvertiserRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	} else if err != nil {
 		return nil, err
 	}
-	return &domain.Advertiser{
-		ID:   advertiser.ID,
-		Name: advertiser.Name,
-	}, nil
+	return convertDBAdvertiserToDomain(advertiser)
+}
+
+// TopUp increases the advertiser balance and records the top-up in the ledger
+func (r *AdvertiserRepository) TopUp(ctx context.Context, advertiserID uuid.UUID, amount float64, currentDate int32) (*domain.Advertiser, error) {
+	amountNum, err := convertCostToNumeric(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	advertiserDB, err := qtx.TopUpAdvertiserBalance(ctx, storage.TopUpAdvertiserBalanceParams{
+		Amount:       amountNum,
+		AdvertiserID: advertiserID,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrAdvertiserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = qtx.CreateLedgerEntry(ctx, storage.CreateLedgerEntryParams{
+		AdvertiserID: advertiserID,
+		Operation:    domain.LedgerTopUp,
+		Amount:       amountNum,
+		Date:         currentDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return convertDBAdvertiserToDomain(advertiserDB)
+}
+
+func (r *AdvertiserRepository) GetLedger(ctx context.Context, advertiserID uuid.UUID, size, offset int) ([]domain.LedgerEntry, error) {
+	entriesDB, err := r.queries.GetLedgerByAdvertiserID(ctx, storage.GetLedgerByAdvertiserIDParams{
+		Limit:        int32(size),
+		Offset:       int32(offset),
+		AdvertiserID: advertiserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.LedgerEntry, len(entriesDB))
+	for i, entryDB := range entriesDB {
+		amount, err := convertNumericToFloat(entryDB.Amount)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = domain.LedgerEntry{
+			ID:           entryDB.ID,
+			AdvertiserID: entryDB.AdvertiserID,
+			Operation:    entryDB.Operation,
+			Amount:       amount,
+			Date:         entryDB.Date,
+			CreatedAt:    entryDB.CreatedAt.Time,
+		}
+		if entryDB.CampaignID.Valid {
+			campaignID := uuid.UUID(entryDB.CampaignID.Bytes)
+			entries[i].CampaignID = &campaignID
+		}
+	}
+	return entries, nil
+}
+
+func (r *AdvertiserRepository) GetBalances(ctx context.Context) ([]domain.AdvertiserBalance, error) {
+	balancesDB, err := r.queries.GetAdvertisersBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]domain.AdvertiserBalance, len(balancesDB))
+	for i, balanceDB := range balancesDB {
+		balance, err := convertNumericToFloat(balanceDB.Balance)
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = domain.AdvertiserBalance{
+			AdvertiserID: balanceDB.ID,
+			Balance:      balance,
+		}
+	}
+	return balances, nil
 }
 
 func (r *AdvertiserRepository) CreateMLScore(ctx context.Context, score *domain.MLScore) error {
@@ -86,6 +184,40 @@ func (r *AdvertiserRepository) GetMLScore(ctx context.Context, clientID, adverti
 	return nil, err
 }
 
+func (r *AdvertiserRepository) GetMLScoresByClientID(ctx context.Context, clientID uuid.UUID) ([]domain.MLScore, error) {
+	scoresDB, err := r.queries.GetMLScoresByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]domain.MLScore, len(scoresDB))
+	for i, scoreDB := range scoresDB {
+		scores[i] = domain.MLScore{
+			ClientID:     scoreDB.ClientID,
+			AdvertiserID: scoreDB.AdvertiserID,
+			Score:        scoreDB.Score,
+		}
+	}
+	return scores, nil
+}
+
+func (r *AdvertiserRepository) GetMLScoresByAdvertiserID(ctx context.Context, advertiserID uuid.UUID) ([]domain.MLScore, error) {
+	scoresDB, err := r.queries.GetMLScoresByAdvertiserID(ctx, advertiserID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]domain.MLScore, len(scoresDB))
+	for i, scoreDB := range scoresDB {
+		scores[i] = domain.MLScore{
+			ClientID:     scoreDB.ClientID,
+			AdvertiserID: scoreDB.AdvertiserID,
+			Score:        scoreDB.Score,
+		}
+	}
+	return scores, nil
+}
+
 func (r *AdvertiserRepository) UpdateMLScore(ctx context.Context, score *domain.MLScore) error {
 	err := r.queries.UpdateMLScore(ctx, storage.UpdateMLScoreParams{
 		Score:        score.Score,
@@ -97,3 +229,15 @@ func (r *AdvertiserRepository) UpdateMLScore(ctx context.Context, score *domain.
 	}
 	return nil
 }
+
+func convertDBAdvertiserToDomain(advertiserDB storage.Advertiser) (*domain.Advertiser, error) {
+	balance, err := convertNumericToFloat(advertiserDB.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Advertiser{
+		ID:      advertiserDB.ID,
+		Name:    advertiserDB.Name,
+		Balance: balance,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/infrastructure/db/sqlc/storage"
+)
+
+type AudienceRepository struct {
+	queries *storage.Queries
+	dbConn  *pgxpool.Pool
+}
+
+func NewAudienceRepository(queries *storage.Queries, dbConn *pgxpool.Pool) *AudienceRepository {
+	return &AudienceRepository{
+		queries: queries,
+		dbConn:  dbConn,
+	}
+}
+
+// CreateAudience creates the audience with its clients list in transaction
+func (r *AudienceRepository) CreateAudience(ctx context.Context, advertiserID uuid.UUID, audienceRequest domain.AudienceRequest) (*domain.Audience, error) {
+	targeting, err := json.Marshal(audienceRequest.Targeting)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	audienceDB, err := qtx.CreateAudience(ctx, storage.CreateAudienceParams{
+		AdvertiserID: advertiserID,
+		Name:         audienceRequest.Name,
+		Targeting:    targeting,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(audienceRequest.ClientIDs) > 0 {
+		err = qtx.AddAudienceClients(ctx, storage.AddAudienceClientsParams{
+			AudienceID: audienceDB.ID,
+			ClientIds:  audienceRequest.ClientIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	audience, err := convertDBAudienceToDomain(audienceDB)
+	if err != nil {
+		return nil, err
+	}
+	audience.ClientIDs = audienceRequest.ClientIDs
+	return &audience, nil
+}
+
+// UpdateAudience replaces the audience name, targeting and clients list
+func (r *AudienceRepository) UpdateAudience(ctx context.Context, audienceID uuid.UUID, audienceRequest domain.AudienceRequest) (*domain.Audience, error) {
+	targeting, err := json.Marshal(audienceRequest.Targeting)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	audienceDB, err := qtx.UpdateAudience(ctx, storage.UpdateAudienceParams{
+		ID:        audienceID,
+		Name:      audienceRequest.Name,
+		Targeting: targeting,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := qtx.DeleteAudienceClients(ctx, audienceID); err != nil {
+		return nil, err
+	}
+	if len(audienceRequest.ClientIDs) > 0 {
+		err = qtx.AddAudienceClients(ctx, storage.AddAudienceClientsParams{
+			AudienceID: audienceID,
+			ClientIds:  audienceRequest.ClientIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	audience, err := convertDBAudienceToDomain(audienceDB)
+	if err != nil {
+		return nil, err
+	}
+	audience.ClientIDs = audienceRequest.ClientIDs
+	return &audience, nil
+}
+
+func (r *AudienceRepository) GetByID(ctx context.Context, audienceID uuid.UUID) (*domain.Audience, error) {
+	audienceDB, err := r.queries.GetAudienceByID(ctx, audienceID)
+	if err != nil {
+		return nil, err
+	}
+
+	audiences, err := r.withClients(ctx, []storage.Audience{audienceDB})
+	if err != nil {
+		return nil, err
+	}
+	return &audiences[0], nil
+}
+
+func (r *AudienceRepository) GetByAdvertiserID(ctx context.Context, advertiserID uuid.UUID, size, offset int) ([]domain.Audience, error) {
+	audiencesDB, err := r.queries.GetAudiencesByAdvertiserID(ctx, storage.GetAudiencesByAdvertiserIDParams{
+		AdvertiserID: advertiserID,
+		Limit:        int32(size),
+		Offset:       int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withClients(ctx, audiencesDB)
+}
+
+// GetByIDs returns audiences with client IDs sorted, missing audiences are skipped
+func (r *AudienceRepository) GetByIDs(ctx context.Context, audienceIDs []uuid.UUID) ([]domain.Audience, error) {
+	if len(audienceIDs) == 0 {
+		return []domain.Audience{}, nil
+	}
+
+	audiencesDB, err := r.queries.GetAudiencesByIDs(ctx, audienceIDs)
+	if err != nil {
+		return nil, err
+	}
+	return r.withClients(ctx, audiencesDB)
+}
+
+// DeleteAudience deletes the audience if no campaigns except archived and deleted use it.
+// Returns false if the audience is in use
+func (r *AudienceRepository) DeleteAudience(ctx context.Context, audienceID uuid.UUID) (bool, error) {
+	deleted, err := r.queries.DeleteUnusedAudience(ctx, audienceID)
+	if err != nil {
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
+// withClients converts audiences and loads their clients in one query
+func (r *AudienceRepository) withClients(ctx context.Context, audiencesDB []storage.Audience) ([]domain.Audience, error) {
+	audiences := make([]domain.Audience, len(audiencesDB))
+	ids := make([]uuid.UUID, len(audiencesDB))
+	positions := make(map[uuid.UUID]int, len(audiencesDB))
+	for i, audienceDB := range audiencesDB {
+		audience, err := convertDBAudienceToDomain(audienceDB)
+		if err != nil {
+			return nil, err
+		}
+		audiences[i] = audience
+		ids[i] = audience.ID
+		positions[audience.ID] = i
+	}
+	if len(ids) == 0 {
+		return audiences, nil
+	}
+
+	// Clients are ordered by client ID inside each audience
+	clients, err := r.queries.GetAudienceClients(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		i := positions[client.AudienceID]
+		audiences[i].ClientIDs = append(audiences[i].ClientIDs, client.ClientID)
+	}
+	return audiences, nil
+}
+
+func convertDBAudienceToDomain(audienceDB storage.Audience) (domain.Audience, error) {
+	audience := domain.Audience{
+		ID:           audienceDB.ID,
+		AdvertiserID: audienceDB.AdvertiserID,
+		Name:         audienceDB.Name,
+	}
+	if err := json.Unmarshal(audienceDB.Targeting, &audience.Targeting); err != nil {
+		return domain.Audience{}, err
+	}
+	return audience, nil
+}
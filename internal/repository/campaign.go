@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"log"
+	"encoding/json"
 	"strconv"
 
 	"github.com/google/uuid"
@@ -35,6 +35,15 @@ func (r *CampaignRepository) CreateCampaign(ctx context.Context, advertiserID uu
 		return nil, err
 	}
 
+	budgetTotal, err := convertFloatPtrToNumeric(campaignRequest.BudgetTotal)
+	if err != nil {
+		return nil, err
+	}
+	budgetDaily, err := convertFloatPtrToNumeric(campaignRequest.BudgetDaily)
+	if err != nil {
+		return nil, err
+	}
+
 	if campaignRequest.StartDate < int32(currentDate) || campaignRequest.EndDate < int32(currentDate) || campaignRequest.EndDate < campaignRequest.StartDate {
 		return nil, domain.ErrBadRequest
 	}
@@ -57,33 +66,43 @@ func (r *CampaignRepository) CreateCampaign(ctx context.Context, advertiserID uu
 		AdText:            campaignRequest.AdText,
 		StartDate:         campaignRequest.StartDate,
 		EndDate:           campaignRequest.EndDate,
+		FrequencyCapTotal: convertInt32PtrToPg(campaignRequest.FrequencyCapTotal),
+		FrequencyCapDaily: convertInt32PtrToPg(campaignRequest.FrequencyCapDaily),
+		BudgetTotal:       budgetTotal,
+		BudgetDaily:       budgetDaily,
+		Pacing:            convertStringPtrToPg(campaignRequest.Pacing),
+		Status:            convertStringPtrToPg(campaignRequest.Status),
+		AudienceID:        convertUUIDPtrToPg(campaignRequest.AudienceID),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	createCampaignTargetingParams := buildCampaignTargetingParams(campaignRequest.Targeting)
+	createCampaignTargetingParams, err := buildCampaignTargetingParams(campaignRequest.Targeting)
+	if err != nil {
+		return nil, err
+	}
 	createCampaignTargetingParams.CampaignID = campaignDB.ID
 
 	targetingDB, err := qtx.CreateCampaignTargeting(ctx, createCampaignTargetingParams)
 	if err != nil {
 		return nil, err
 	}
-	tx.Commit(ctx)
 
-	campaign := domain.Campaign{
-		ID:                campaignDB.ID,
-		AdvertiserID:      campaignDB.AdvertiserID,
-		ImpressionsLimit:  campaignDB.ImpressionsLimit,
-		ClicksLimit:       campaignDB.ClicksLimit,
-		CostPerImpression: campaignRequest.CostPerImpression,
-		CostPerClick:      campaignRequest.CostPerClick,
-		AdTitle:           campaignDB.AdTitle,
-		AdText:            campaignDB.AdText,
-		StartDate:         campaignDB.StartDate,
-		EndDate:           campaignDB.EndDate,
-		Targeting:         convertDBTargetingToDomain(targetingDB),
+	campaign, err := convertDBCampaignToDomain(campaignDB)
+	if err != nil {
+		return nil, err
+	}
+	campaign.Targeting, err = convertDBTargetingToDomain(targetingDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createCampaignVersion(ctx, qtx, campaign, currentDate); err != nil {
+		return nil, err
 	}
+	tx.Commit(ctx)
+
 	return &campaign, nil
 }
 
@@ -105,50 +124,16 @@ func (r *CampaignRepository) GetCampaignsByAdvertiserID(ctx context.Context, adv
 	}
 
 	campaigns := make([]domain.Campaign, len(campaignsDB))
-
 	for i, campaignDB := range campaignsDB {
-		// Convert cost per impression and cost per click to float64 values
-		var costPerImpression float64
-		costPerImpressionFloatDB, err := campaignDB.CostPerImpression.Float64Value()
+		campaign, err := convertDBCampaignToDomain(campaignDB.Campaign)
 		if err != nil {
 			return nil, err
 		}
-		costPerImpression = costPerImpressionFloatDB.Float64
-
-		var costPerClick float64
-		costPerClickFloatDB, err := campaignDB.CostPerClick.Float64Value()
+		campaign.Targeting, err = convertDBTargetingToDomain(campaignDB.CampaignsTargeting)
 		if err != nil {
 			return nil, err
 		}
-		costPerClick = costPerClickFloatDB.Float64
-
-		targeting := domain.Targeting{}
-
-		if campaignDB.Gender.Valid {
-			targeting.Gender = &campaignDB.Gender.String
-		}
-		if campaignDB.AgeFrom.Valid {
-			targeting.AgeFrom = &campaignDB.AgeFrom.Int32
-		}
-		if campaignDB.AgeTo.Valid {
-			targeting.AgeTo = &campaignDB.AgeTo.Int32
-		}
-		if campaignDB.Location.Valid {
-			targeting.Location = &campaignDB.Location.String
-		}
-		campaigns[i] = domain.Campaign{
-			ID:                campaignDB.ID,
-			AdvertiserID:      advertiserID,
-			ImpressionsLimit:  campaignDB.ImpressionsLimit,
-			ClicksLimit:       campaignDB.ClicksLimit,
-			CostPerImpression: costPerImpression,
-			CostPerClick:      costPerClick,
-			AdTitle:           campaignDB.AdTitle,
-			AdText:            campaignDB.AdText,
-			StartDate:         campaignDB.StartDate,
-			EndDate:           campaignDB.EndDate,
-			Targeting:         targeting,
-		}
+		campaigns[i] = campaign
 	}
 
 	return campaigns, nil
@@ -168,49 +153,75 @@ func (r *CampaignRepository) GetCampaignByID(ctx context.Context, campaignID uui
 		return nil, err
 	}
 
-	// Convert cost per impression and cost per click to float64 values
-	var costPerImpression float64
-	costPerImpressionFloatDB, err := campaignDB.CostPerImpression.Float64Value()
+	campaign, err := convertDBCampaignToDomain(campaignDB.Campaign)
 	if err != nil {
 		return nil, err
 	}
-	costPerImpression = costPerImpressionFloatDB.Float64
-
-	var costPerClick float64
-	costPerClickFloatDB, err := campaignDB.CostPerClick.Float64Value()
+	campaign.Targeting, err = convertDBTargetingToDomain(campaignDB.CampaignsTargeting)
 	if err != nil {
 		return nil, err
 	}
-	costPerClick = costPerClickFloatDB.Float64
+	return &campaign, nil
+}
 
-	targeting := domain.Targeting{}
+// GetCampaignByIDIncludingDeleted returns the campaign even if it was deleted
+func (r *CampaignRepository) GetCampaignByIDIncludingDeleted(ctx context.Context, campaignID uuid.UUID) (*domain.Campaign, error) {
+	campaignDB, err := r.queries.GetCampaignWithTargetingByIDIncludingDeleted(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
 
-	if campaignDB.Gender.Valid {
-		targeting.Gender = &campaignDB.Gender.String
+	campaign, err := convertDBCampaignToDomain(campaignDB.Campaign)
+	if err != nil {
+		return nil, err
 	}
-	if campaignDB.AgeFrom.Valid {
-		targeting.AgeFrom = &campaignDB.AgeFrom.Int32
+	campaign.Targeting, err = convertDBTargetingToDomain(campaignDB.CampaignsTargeting)
+	if err != nil {
+		return nil, err
 	}
-	if campaignDB.AgeTo.Valid {
-		targeting.AgeTo = &campaignDB.AgeTo.Int32
+	return &campaign, nil
+}
+
+// GetActiveCampaigns returns campaigns that are running on the current date
+func (r *CampaignRepository) GetActiveCampaigns(ctx context.Context, currentDate int32) ([]domain.Campaign, error) {
+	campaignsDB, err := r.queries.GetActiveCampaignsWithTargeting(ctx, currentDate)
+	if err != nil {
+		return nil, err
 	}
-	if campaignDB.Location.Valid {
-		targeting.Location = &campaignDB.Location.String
+
+	campaigns := make([]domain.Campaign, len(campaignsDB))
+	for i, campaignDB := range campaignsDB {
+		campaign, err := convertDBCampaignToDomain(campaignDB.Campaign)
+		if err != nil {
+			return nil, err
+		}
+		campaign.Targeting, err = convertDBTargetingToDomain(campaignDB.CampaignsTargeting)
+		if err != nil {
+			return nil, err
+		}
+		campaigns[i] = campaign
 	}
+	return campaigns, nil
+}
 
-	return &domain.Campaign{
-		ID:                campaignDB.ID,
-		AdvertiserID:      campaignDB.AdvertiserID,
-		ImpressionsLimit:  campaignDB.ImpressionsLimit,
-		ClicksLimit:       campaignDB.ClicksLimit,
-		CostPerImpression: costPerImpression,
-		CostPerClick:      costPerClick,
-		AdTitle:           campaignDB.AdTitle,
-		AdText:            campaignDB.AdText,
-		StartDate:         campaignDB.StartDate,
-		EndDate:           campaignDB.EndDate,
-		Targeting:         targeting,
-	}, nil
+// GetCampaignSpent returns money spent by the campaign in total and on the current date
+func (r *CampaignRepository) GetCampaignSpent(ctx context.Context, campaignID uuid.UUID, currentDate int32) (float64, float64, error) {
+	spentDB, err := r.queries.GetCampaignSpent(ctx, storage.GetCampaignSpentParams{
+		CampaignID: campaignID,
+		CurDate:    currentDate,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	spentTotal, err := convertNumericToFloat(spentDB.SpentTotal)
+	if err != nil {
+		return 0, 0, err
+	}
+	spentDaily, err := convertNumericToFloat(spentDB.SpentDaily)
+	if err != nil {
+		return 0, 0, err
+	}
+	return spentTotal, spentDaily, nil
 }
 
 func (r *CampaignRepository) UpdateCampaign(ctx context.Context, campaignID uuid.UUID, campaignUpdate domain.CampaignUpdateRequest, currentDate int) (*domain.Campaign, error) {
@@ -224,23 +235,13 @@ func (r *CampaignRepository) UpdateCampaign(ctx context.Context, campaignID uuid
 		return nil, err
 	}
 
-	var started bool
-	existingCampaignDB, err := r.queries.GetCampaignWithTargetingByID(ctx, campaignID)
-	started = existingCampaignDB.StartDate <= int32(currentDate)
-	log.Printf("updating campaign %s, started: %v", existingCampaignDB.ID, started)
-
-	var impressionsLimit, clicksLimit int64
-	var startDate, endDate int32
-	if started {
-		impressionsLimit = existingCampaignDB.ImpressionsLimit
-		clicksLimit = existingCampaignDB.ClicksLimit
-		startDate = existingCampaignDB.StartDate
-		endDate = existingCampaignDB.EndDate
-	} else {
-		impressionsLimit = campaignUpdate.ImpressionsLimit
-		clicksLimit = campaignUpdate.ClicksLimit
-		startDate = campaignUpdate.StartDate
-		endDate = campaignUpdate.EndDate
+	budgetTotal, err := convertFloatPtrToNumeric(campaignUpdate.BudgetTotal)
+	if err != nil {
+		return nil, err
+	}
+	budgetDaily, err := convertFloatPtrToNumeric(campaignUpdate.BudgetDaily)
+	if err != nil {
+		return nil, err
 	}
 
 	tx, err := r.dbConn.Begin(ctx)
@@ -252,45 +253,74 @@ func (r *CampaignRepository) UpdateCampaign(ctx context.Context, campaignID uuid
 
 	campaignDB, err := qtx.UpdateCampaign(ctx, storage.UpdateCampaignParams{
 		CampaignID:        campaignID,
-		ImpressionsLimit:  impressionsLimit,
-		ClicksLimit:       clicksLimit,
+		ImpressionsLimit:  campaignUpdate.ImpressionsLimit,
+		ClicksLimit:       campaignUpdate.ClicksLimit,
 		CostPerImpression: costPerImpression,
 		CostPerClick:      costPerClick,
 		AdTitle:           campaignUpdate.AdTitle,
 		AdText:            campaignUpdate.AdText,
-		StartDate:         startDate,
-		EndDate:           endDate,
+		StartDate:         campaignUpdate.StartDate,
+		EndDate:           campaignUpdate.EndDate,
+		FrequencyCapTotal: convertInt32PtrToPg(campaignUpdate.FrequencyCapTotal),
+		FrequencyCapDaily: convertInt32PtrToPg(campaignUpdate.FrequencyCapDaily),
+		BudgetTotal:       budgetTotal,
+		BudgetDaily:       budgetDaily,
+		Pacing:            convertStringPtrToPg(campaignUpdate.Pacing),
+		AudienceID:        convertUUIDPtrToPg(campaignUpdate.AudienceID),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	updateCampaignTargetingParams := buildUpdateCampaignTargetingParams(campaignUpdate.Targeting)
+	updateCampaignTargetingParams, err := buildUpdateCampaignTargetingParams(campaignUpdate.Targeting)
+	if err != nil {
+		return nil, err
+	}
 	updateCampaignTargetingParams.CampaignID = campaignDB.ID
 
 	targetingDB, err := qtx.UpdateCampaignTargeting(ctx, updateCampaignTargetingParams)
 	if err != nil {
 		return nil, err
 	}
-	tx.Commit(ctx)
 
-	campaign := domain.Campaign{
-		ID:                campaignDB.ID,
-		AdvertiserID:      campaignDB.AdvertiserID,
-		ImpressionsLimit:  campaignDB.ImpressionsLimit,
-		ClicksLimit:       campaignDB.ClicksLimit,
-		CostPerImpression: campaignUpdate.CostPerImpression,
-		CostPerClick:      campaignUpdate.CostPerClick,
-		AdTitle:           campaignDB.AdTitle,
-		AdText:            campaignDB.AdText,
-		StartDate:         campaignDB.StartDate,
-		EndDate:           campaignDB.EndDate,
-		Targeting:         convertDBTargetingToDomain(targetingDB),
+	campaign, err := convertDBCampaignToDomain(campaignDB)
+	if err != nil {
+		return nil, err
 	}
+	campaign.Targeting, err = convertDBTargetingToDomain(targetingDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createCampaignVersion(ctx, qtx, campaign, currentDate); err != nil {
+		return nil, err
+	}
+	tx.Commit(ctx)
+
 	return &campaign, nil
 }
 
+func (r *CampaignRepository) SetCampaignStatus(ctx context.Context, campaignID uuid.UUID, status string) error {
+	_, err := r.queries.SetCampaignStatus(ctx, storage.SetCampaignStatusParams{
+		Status:     status,
+		CampaignID: campaignID,
+	})
+	return err
+}
+
+// GetUnfinishedCampaignIDs returns IDs of campaigns that are not deleted and not finished yet
+func (r *CampaignRepository) GetUnfinishedCampaignIDs(ctx context.Context, currentDate int32) ([]uuid.UUID, error) {
+	return r.queries.GetUnfinishedCampaignIDs(ctx, currentDate)
+}
+
+// DeleteCampaign marks the campaign as deleted, its impressions, clicks and ledger entries are kept
 func (r *CampaignRepository) DeleteCampaign(ctx context.Context, campaignID uuid.UUID) error {
+	err := r.queries.SoftDeleteCampaignByID(ctx, campaignID)
+	return err
+}
+
+// PurgeCampaign deletes the campaign with its impressions and clicks
+func (r *CampaignRepository) PurgeCampaign(ctx context.Context, campaignID uuid.UUID) error {
 	err := r.queries.DeleteCampaignByID(ctx, campaignID)
 	return err
 }
@@ -304,7 +334,88 @@ func convertCostToNumeric(cost float64) (pgtype.Numeric, error) {
 	return num, nil
 }
 
-func buildCampaignTargetingParams(targeting domain.Targeting) storage.CreateCampaignTargetingParams {
+func convertDBCampaignToDomain(campaignDB storage.Campaign) (domain.Campaign, error) {
+	costPerImpression, err := convertNumericToFloat(campaignDB.CostPerImpression)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+	costPerClick, err := convertNumericToFloat(campaignDB.CostPerClick)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+	budgetTotal, err := convertNumericToFloat(campaignDB.BudgetTotal)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+	budgetDaily, err := convertNumericToFloat(campaignDB.BudgetDaily)
+	if err != nil {
+		return domain.Campaign{}, err
+	}
+
+	var audienceID *uuid.UUID
+	if campaignDB.AudienceID.Valid {
+		id := uuid.UUID(campaignDB.AudienceID.Bytes)
+		audienceID = &id
+	}
+
+	return domain.Campaign{
+		ID:                campaignDB.ID,
+		AdvertiserID:      campaignDB.AdvertiserID,
+		ImpressionsLimit:  campaignDB.ImpressionsLimit,
+		ClicksLimit:       campaignDB.ClicksLimit,
+		CostPerImpression: costPerImpression,
+		CostPerClick:      costPerClick,
+		AdTitle:           campaignDB.AdTitle,
+		AdText:            campaignDB.AdText,
+		StartDate:         campaignDB.StartDate,
+		EndDate:           campaignDB.EndDate,
+		FrequencyCapTotal: campaignDB.FrequencyCapTotal,
+		FrequencyCapDaily: campaignDB.FrequencyCapDaily,
+		BudgetTotal:       budgetTotal,
+		BudgetDaily:       budgetDaily,
+		Pacing:            campaignDB.Pacing,
+		Status:            campaignDB.Status,
+		AudienceID:        audienceID,
+	}, nil
+}
+
+func convertInt32PtrToPg(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}
+}
+
+func convertStringPtrToPg(value *string) pgtype.Text {
+	if value == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *value, Valid: true}
+}
+
+func convertUUIDPtrToPg(value *uuid.UUID) pgtype.UUID {
+	if value == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *value, Valid: true}
+}
+
+func convertFloatPtrToNumeric(value *float64) (pgtype.Numeric, error) {
+	if value == nil {
+		return pgtype.Numeric{}, nil
+	}
+	return convertCostToNumeric(*value)
+}
+
+func convertNumericToFloat(num pgtype.Numeric) (float64, error) {
+	numFloat, err := num.Float64Value()
+	if err != nil {
+		return 0, err
+	}
+	return numFloat.Float64, nil
+}
+
+func buildCampaignTargetingParams(targeting domain.Targeting) (storage.CreateCampaignTargetingParams, error) {
 	params := storage.CreateCampaignTargetingParams{}
 	if targeting.Gender != nil {
 		params.Gender = pgtype.Text{String: *targeting.Gender, Valid: true}
@@ -318,10 +429,22 @@ func buildCampaignTargetingParams(targeting domain.Targeting) storage.CreateCamp
 	if targeting.Location != nil {
 		params.Location = pgtype.Text{String: *targeting.Location, Valid: true}
 	}
-	return params
+	params.Genders = targeting.Genders
+	params.Locations = targeting.Locations
+	params.ExcludedLocations = targeting.ExcludedLocations
+	params.Interests = targeting.Interests
+	if targeting.InterestsMode != nil {
+		params.InterestsMode = pgtype.Text{String: *targeting.InterestsMode, Valid: true}
+	}
+	rules, err := marshalTargetingRules(targeting.Rules)
+	if err != nil {
+		return params, err
+	}
+	params.Rules = rules
+	return params, nil
 }
 
-func buildUpdateCampaignTargetingParams(targeting domain.Targeting) storage.UpdateCampaignTargetingParams {
+func buildUpdateCampaignTargetingParams(targeting domain.Targeting) (storage.UpdateCampaignTargetingParams, error) {
 	params := storage.UpdateCampaignTargetingParams{}
 	if targeting.Gender != nil {
 		params.Gender = pgtype.Text{String: *targeting.Gender, Valid: true}
@@ -335,10 +458,22 @@ func buildUpdateCampaignTargetingParams(targeting domain.Targeting) storage.Upda
 	if targeting.Location != nil {
 		params.Location = pgtype.Text{String: *targeting.Location, Valid: true}
 	}
-	return params
+	params.Genders = targeting.Genders
+	params.Locations = targeting.Locations
+	params.ExcludedLocations = targeting.ExcludedLocations
+	params.Interests = targeting.Interests
+	if targeting.InterestsMode != nil {
+		params.InterestsMode = pgtype.Text{String: *targeting.InterestsMode, Valid: true}
+	}
+	rules, err := marshalTargetingRules(targeting.Rules)
+	if err != nil {
+		return params, err
+	}
+	params.Rules = rules
+	return params, nil
 }
 
-func convertDBTargetingToDomain(targetingDB storage.CampaignsTargeting) domain.Targeting {
+func convertDBTargetingToDomain(targetingDB storage.CampaignsTargeting) (domain.Targeting, error) {
 	targeting := domain.Targeting{}
 	if targetingDB.Gender.Valid {
 		targeting.Gender = &targetingDB.Gender.String
@@ -352,5 +487,95 @@ func convertDBTargetingToDomain(targetingDB storage.CampaignsTargeting) domain.T
 	if targetingDB.Location.Valid {
 		targeting.Location = &targetingDB.Location.String
 	}
-	return targeting
+	if len(targetingDB.Genders) > 0 {
+		targeting.Genders = targetingDB.Genders
+	}
+	if len(targetingDB.Locations) > 0 {
+		targeting.Locations = targetingDB.Locations
+	}
+	if len(targetingDB.ExcludedLocations) > 0 {
+		targeting.ExcludedLocations = targetingDB.ExcludedLocations
+	}
+	if len(targetingDB.Interests) > 0 {
+		targeting.Interests = targetingDB.Interests
+	}
+	if targetingDB.InterestsMode.Valid {
+		targeting.InterestsMode = &targetingDB.InterestsMode.String
+	}
+	if len(targetingDB.Rules) > 0 {
+		if err := json.Unmarshal(targetingDB.Rules, &targeting.Rules); err != nil {
+			return domain.Targeting{}, err
+		}
+	}
+	return targeting, nil
+}
+
+// marshalTargetingRules converts the rules to JSONB, nil rules are stored as NULL
+func marshalTargetingRules(rules *domain.TargetingRule) ([]byte, error) {
+	if rules == nil {
+		return nil, nil
+	}
+	return json.Marshal(rules)
+}
+
+// createCampaignVersion saves the snapshot of the campaign as its next version
+func createCampaignVersion(ctx context.Context, qtx *storage.Queries, campaign domain.Campaign, currentDate int) error {
+	snapshot, err := json.Marshal(campaign)
+	if err != nil {
+		return err
+	}
+	_, err = qtx.CreateCampaignVersion(ctx, storage.CreateCampaignVersionParams{
+		CampaignID: campaign.ID,
+		Date:       int32(currentDate),
+		Snapshot:   snapshot,
+	})
+	return err
+}
+
+func (r *CampaignRepository) GetCampaignVersions(ctx context.Context, campaignID uuid.UUID, size, offset int) ([]domain.CampaignVersion, error) {
+	versionsDB, err := r.queries.GetCampaignVersions(ctx, storage.GetCampaignVersionsParams{
+		CampaignID: campaignID,
+		Limit:      int32(size),
+		Offset:     int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]domain.CampaignVersion, len(versionsDB))
+	for i, versionDB := range versionsDB {
+		version, err := convertDBCampaignVersionToDomain(versionDB)
+		if err != nil {
+			return nil, err
+		}
+		versions[i] = version
+	}
+	return versions, nil
+}
+
+func (r *CampaignRepository) GetCampaignVersion(ctx context.Context, campaignID uuid.UUID, version int32) (*domain.CampaignVersion, error) {
+	versionDB, err := r.queries.GetCampaignVersion(ctx, storage.GetCampaignVersionParams{
+		CampaignID: campaignID,
+		Version:    version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	campaignVersion, err := convertDBCampaignVersionToDomain(versionDB)
+	if err != nil {
+		return nil, err
+	}
+	return &campaignVersion, nil
+}
+
+func convertDBCampaignVersionToDomain(versionDB storage.CampaignVersion) (domain.CampaignVersion, error) {
+	version := domain.CampaignVersion{
+		Version: versionDB.Version,
+		Date:    versionDB.Date,
+	}
+	if err := json.Unmarshal(versionDB.Snapshot, &version.Campaign); err != nil {
+		return domain.CampaignVersion{}, err
+	}
+	return version, nil
 }
@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+)
+
+// countersSyncedKey marks redis counters as loaded from the database. Its version
+// must be bumped when a new counter family is added, so redis synced by
+// an older version is loaded again with the new counters
+const countersSyncedKey = "counters_synced:v4"
+
+type CounterRepository struct {
+	rdb *redis.Client
+}
+
+func NewCounterRepository(rdb *redis.Client) *CounterRepository {
+	return &CounterRepository{
+		rdb: rdb,
+	}
+}
+
+// staleCountersBatch is how many stale keys are scanned and deleted at once
+const staleCountersBatch = 1000
+
+func impressionsKey(campaignID uuid.UUID) string {
+	return fmt.Sprintf("impressions:%s", campaignID)
+}
+
+func billedClicksKey(campaignID uuid.UUID) string {
+	return fmt.Sprintf("clicks:%s", campaignID)
+}
+
+func clientImpressionsKey(campaignID, clientID uuid.UUID) string {
+	return fmt.Sprintf("impressions:%s:%s", campaignID, clientID)
+}
+
+func clientDailyImpressionsKey(campaignID, clientID uuid.UUID, date int32) string {
+	return fmt.Sprintf("impressions:%s:%s:%d", campaignID, clientID, date)
+}
+
+func dailyImpressionsKey(campaignID uuid.UUID, date int32) string {
+	return fmt.Sprintf("daily_impressions:%s:%d", campaignID, date)
+}
+
+func balanceKey(advertiserID uuid.UUID) string {
+	return fmt.Sprintf("balance:%s", advertiserID)
+}
+
+func spentKey(campaignID uuid.UUID) string {
+	return fmt.Sprintf("spent:%s", campaignID)
+}
+
+func dailySpentKey(campaignID uuid.UUID, date int32) string {
+	return fmt.Sprintf("spent:%s:%d", campaignID, date)
+}
+
+// GetCounters returns counters of the campaigns for the client in the same order as campaigns
+func (r *CounterRepository) GetCounters(ctx context.Context, campaigns []domain.Campaign, clientID uuid.UUID, currentDate int32) ([]domain.AdCounters, error) {
+	if len(campaigns) == 0 {
+		return []domain.AdCounters{}, nil
+	}
+
+	const keysPerCampaign = 8
+	keys := make([]string, 0, len(campaigns)*keysPerCampaign)
+	for _, campaign := range campaigns {
+		campaignID := campaign.ID
+		keys = append(keys,
+			impressionsKey(campaignID),
+			billedClicksKey(campaignID),
+			clientImpressionsKey(campaignID, clientID),
+			clientDailyImpressionsKey(campaignID, clientID, currentDate),
+			spentKey(campaignID),
+			dailySpentKey(campaignID, currentDate),
+			dailyImpressionsKey(campaignID, currentDate),
+			balanceKey(campaign.AdvertiserID),
+		)
+	}
+
+	values, err := r.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// Spent counters are floats, so all values are parsed as floats
+	parsed := make([]float64, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		valueStr, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected counter value type %T", value)
+		}
+		parsed[i], err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	counters := make([]domain.AdCounters, len(campaigns))
+	for i := range campaigns {
+		campaignValues := parsed[i*keysPerCampaign : (i+1)*keysPerCampaign]
+		counters[i] = domain.AdCounters{
+			Impressions:            int64(campaignValues[0]),
+			BilledClicks:           int64(campaignValues[1]),
+			ClientImpressions:      int64(campaignValues[2]),
+			ClientDailyImpressions: int64(campaignValues[3]),
+			Spent:                  campaignValues[4],
+			DailySpent:             campaignValues[5],
+			DailyImpressions:       int64(campaignValues[6]),
+			Balance:                campaignValues[7],
+		}
+	}
+	return counters, nil
+}
+
+// reserveImpressionScript increments impression counters and spent money only
+// if the campaign impressions limit, pacing limit, budgets and client frequency
+// caps are not reached yet. The impression cost must fit into the budgets.
+// Caps, budgets and pacing limit equal to 0 mean no cap.
+// Advertiser balance is always charged, but checked only if ARGV[8] is 1: it must
+// cover the impression. Clicks are checked against the balance when they are charged
+var reserveImpressionScript = redis.NewScript(`
+local impressions = tonumber(redis.call("GET", KEYS[1]) or "0")
+if impressions >= tonumber(ARGV[1]) then
+	return 0
+end
+local capTotal = tonumber(ARGV[2])
+if capTotal > 0 and tonumber(redis.call("GET", KEYS[2]) or "0") >= capTotal then
+	return 0
+end
+local capDaily = tonumber(ARGV[3])
+if capDaily > 0 and tonumber(redis.call("GET", KEYS[3]) or "0") >= capDaily then
+	return 0
+end
+local cost = tonumber(ARGV[6])
+local budgetTotal = tonumber(ARGV[4])
+if budgetTotal > 0 then
+	local spent = tonumber(redis.call("GET", KEYS[4]) or "0")
+	if spent >= budgetTotal or spent + cost > budgetTotal then
+		return 0
+	end
+end
+local budgetDaily = tonumber(ARGV[5])
+if budgetDaily > 0 then
+	local dailySpent = tonumber(redis.call("GET", KEYS[5]) or "0")
+	if dailySpent >= budgetDaily or dailySpent + cost > budgetDaily then
+		return 0
+	end
+end
+local pacingLimit = tonumber(ARGV[7])
+if pacingLimit > 0 and tonumber(redis.call("GET", KEYS[6]) or "0") >= pacingLimit then
+	return 0
+end
+if ARGV[8] == "1" then
+	local balance = tonumber(redis.call("GET", KEYS[7]) or "0")
+	if balance <= 0 or balance < cost then
+		return 0
+	end
+end
+redis.call("INCR", KEYS[1])
+redis.call("INCR", KEYS[2])
+redis.call("INCR", KEYS[3])
+redis.call("INCRBYFLOAT", KEYS[4], ARGV[6])
+redis.call("INCRBYFLOAT", KEYS[5], ARGV[6])
+redis.call("INCR", KEYS[6])
+redis.call("INCRBYFLOAT", KEYS[7], -tonumber(ARGV[6]))
+return 1
+`)
+
+// ReserveImpression atomically checks campaign limits and increments impression counters.
+// Returns false if the impression can't be shown
+func (r *CounterRepository) ReserveImpression(ctx context.Context,
+	campaign domain.Campaign,
+	clientID uuid.UUID,
+	currentDate int32,
+	maxImpressions float64,
+	pacingLimit int64,
+	enforceBalance bool) (bool, error) {
+	keys := []string{
+		impressionsKey(campaign.ID),
+		clientImpressionsKey(campaign.ID, clientID),
+		clientDailyImpressionsKey(campaign.ID, clientID, currentDate),
+		spentKey(campaign.ID),
+		dailySpentKey(campaign.ID, currentDate),
+		dailyImpressionsKey(campaign.ID, currentDate),
+		balanceKey(campaign.AdvertiserID),
+	}
+	reserved, err := reserveImpressionScript.Run(ctx, r.rdb, keys,
+		maxImpressions, campaign.FrequencyCapTotal, campaign.FrequencyCapDaily,
+		campaign.BudgetTotal, campaign.BudgetDaily, campaign.CostPerImpression, pacingLimit, enforceBalance).Int()
+	if err != nil {
+		return false, err
+	}
+	return reserved == 1, nil
+}
+
+// ReleaseImpression rolls back the reserved impression if it wasn't saved
+func (r *CounterRepository) ReleaseImpression(ctx context.Context, campaign domain.Campaign, clientID uuid.UUID, currentDate int32) error {
+	pipe := r.rdb.TxPipeline()
+	pipe.Decr(ctx, impressionsKey(campaign.ID))
+	pipe.Decr(ctx, clientImpressionsKey(campaign.ID, clientID))
+	pipe.Decr(ctx, clientDailyImpressionsKey(campaign.ID, clientID, currentDate))
+	pipe.IncrByFloat(ctx, spentKey(campaign.ID), -campaign.CostPerImpression)
+	pipe.IncrByFloat(ctx, dailySpentKey(campaign.ID, currentDate), -campaign.CostPerImpression)
+	pipe.Decr(ctx, dailyImpressionsKey(campaign.ID, currentDate))
+	pipe.IncrByFloat(ctx, balanceKey(campaign.AdvertiserID), campaign.CostPerImpression)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *CounterRepository) IncrBilledClick(ctx context.Context, campaign domain.Campaign, cost float64, currentDate int32) error {
+	pipe := r.rdb.TxPipeline()
+	pipe.Incr(ctx, billedClicksKey(campaign.ID))
+	pipe.IncrByFloat(ctx, spentKey(campaign.ID), cost)
+	pipe.IncrByFloat(ctx, dailySpentKey(campaign.ID, currentDate), cost)
+	pipe.IncrByFloat(ctx, balanceKey(campaign.AdvertiserID), -cost)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// IncrBalance adds the amount to the advertiser balance. Delta is applied instead of
+// copying the database balance, so impressions reserved but not saved yet are kept
+func (r *CounterRepository) IncrBalance(ctx context.Context, advertiserID uuid.UUID, amount float64) error {
+	return r.rdb.IncrByFloat(ctx, balanceKey(advertiserID), amount).Err()
+}
+
+// IsSynced reports if the counters were already loaded from the database
+func (r *CounterRepository) IsSynced(ctx context.Context) (bool, error) {
+	exists, err := r.rdb.Exists(ctx, countersSyncedKey).Result()
+	if err != nil {
+		return false, err
+	}
+	return exists == 1, nil
+}
+
+// Sync overwrites the counters with the given values and marks them as synced
+func (r *CounterRepository) Sync(ctx context.Context,
+	impressions []domain.ImpressionsCounter,
+	clicks []domain.ClicksCounter,
+	spent []domain.SpentCounter,
+	balances []domain.AdvertiserBalance) error {
+	campaignImpressions := make(map[uuid.UUID]int64)
+	campaignDailyImpressions := make(map[uuid.UUID]map[int32]int64)
+	campaignSpent := make(map[uuid.UUID]float64)
+	clientImpressions := make(map[[2]uuid.UUID]int64)
+
+	pipe := r.rdb.Pipeline()
+	for _, counter := range impressions {
+		campaignImpressions[counter.CampaignID] += counter.Count
+		clientImpressions[[2]uuid.UUID{counter.CampaignID, counter.ClientID}] += counter.Count
+		if campaignDailyImpressions[counter.CampaignID] == nil {
+			campaignDailyImpressions[counter.CampaignID] = make(map[int32]int64)
+		}
+		campaignDailyImpressions[counter.CampaignID][counter.Date] += counter.Count
+		pipe.Set(ctx, clientDailyImpressionsKey(counter.CampaignID, counter.ClientID, counter.Date), counter.Count, 0)
+	}
+	for campaignID, count := range campaignImpressions {
+		pipe.Set(ctx, impressionsKey(campaignID), count, 0)
+	}
+	for ids, count := range clientImpressions {
+		pipe.Set(ctx, clientImpressionsKey(ids[0], ids[1]), count, 0)
+	}
+	for campaignID, days := range campaignDailyImpressions {
+		for date, count := range days {
+			pipe.Set(ctx, dailyImpressionsKey(campaignID, date), count, 0)
+		}
+	}
+	for _, counter := range clicks {
+		pipe.Set(ctx, billedClicksKey(counter.CampaignID), counter.Count, 0)
+	}
+	for _, counter := range spent {
+		campaignSpent[counter.CampaignID] += counter.Amount
+		pipe.Set(ctx, dailySpentKey(counter.CampaignID, counter.Date), counter.Amount, 0)
+	}
+	for campaignID, amount := range campaignSpent {
+		pipe.Set(ctx, spentKey(campaignID), amount, 0)
+	}
+	for _, balance := range balances {
+		pipe.Set(ctx, balanceKey(balance.AdvertiserID), balance.Balance, 0)
+	}
+	pipe.Set(ctx, countersSyncedKey, 1, 0)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DeleteStale removes daily counters of past days and all counters of campaigns
+// that can't be shown anymore. Dates are changed through the API, not by the clock,
+// so counters don't have a TTL and are removed when the date changes instead
+func (r *CounterRepository) DeleteStale(ctx context.Context, currentDate int32, unfinishedCampaignIDs []uuid.UUID) error {
+	unfinished := make(map[uuid.UUID]struct{}, len(unfinishedCampaignIDs))
+	for _, campaignID := range unfinishedCampaignIDs {
+		unfinished[campaignID] = struct{}{}
+	}
+
+	var stale []string
+	for _, pattern := range []string{"impressions:*", "clicks:*", "daily_impressions:*", "spent:*"} {
+		iter := r.rdb.Scan(ctx, 0, pattern, staleCountersBatch).Iterator()
+		for iter.Next(ctx) {
+			if !isStaleCounter(iter.Val(), currentDate, unfinished) {
+				continue
+			}
+			stale = append(stale, iter.Val())
+			if len(stale) >= staleCountersBatch {
+				if err := r.rdb.Unlink(ctx, stale...).Err(); err != nil {
+					return err
+				}
+				stale = stale[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+	if len(stale) > 0 {
+		return r.rdb.Unlink(ctx, stale...).Err()
+	}
+	return nil
+}
+
+// isStaleCounter checks if the counter belongs to a finished campaign
+// or is a daily counter of a past day. Daily counters end with the date
+func isStaleCounter(key string, currentDate int32, unfinished map[uuid.UUID]struct{}) bool {
+	parts := strings.Split(key, ":")
+	if len(parts) < 2 {
+		return false
+	}
+	campaignID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return false
+	}
+	if _, ok := unfinished[campaignID]; !ok {
+		return true
+	}
+
+	var daily bool
+	switch parts[0] {
+	case "impressions":
+		daily = len(parts) == 4
+	case "daily_impressions", "spent":
+		daily = len(parts) == 3
+	}
+	if !daily {
+		return false
+	}
+	date, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return false
+	}
+	return int32(date) < currentDate
+}
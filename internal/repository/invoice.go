@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/infrastructure/db/sqlc/storage"
+)
+
+type InvoiceRepository struct {
+	queries *storage.Queries
+}
+
+func NewInvoiceRepository(queries *storage.Queries) *InvoiceRepository {
+	return &InvoiceRepository{
+		queries: queries,
+	}
+}
+
+func (r *InvoiceRepository) GetInvoiceItems(ctx context.Context, advertiserID uuid.UUID, from, to int32) ([]domain.InvoiceItem, error) {
+	itemsDB, err := r.queries.GetAdvertiserInvoiceItems(ctx, storage.GetAdvertiserInvoiceItemsParams{
+		FromDate:     from,
+		ToDate:       to,
+		AdvertiserID: advertiserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.InvoiceItem, len(itemsDB))
+	for i, itemDB := range itemsDB {
+		impressionsAmount, err := convertNumericToFloat(itemDB.ImpressionsAmount)
+		if err != nil {
+			return nil, err
+		}
+		clicksAmount, err := convertNumericToFloat(itemDB.ClicksAmount)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = domain.InvoiceItem{
+			CampaignID:        itemDB.CampaignID,
+			AdTitle:           itemDB.AdTitle,
+			ImpressionsCount:  itemDB.ImpressionsCount,
+			ImpressionsAmount: impressionsAmount,
+			ClicksCount:       itemDB.ClicksCount,
+			ClicksAmount:      clicksAmount,
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
+	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/infrastructure/db/sqlc/storage"
+)
+
+type StatsRepository struct {
+	queries *storage.Queries
+}
+
+func NewStatsRepository(queries *storage.Queries) *StatsRepository {
+	return &StatsRepository{
+		queries: queries,
+	}
+}
+
+func (r *StatsRepository) GetCampaignStats(ctx context.Context, campaignID uuid.UUID) (*domain.CampaignStats, error) {
+	statsDB, err := r.queries.GetCampaignStats(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	spentImpressions, err := convertNumericToFloat(statsDB.SpentImpressions)
+	if err != nil {
+		return nil, err
+	}
+	spentClicks, err := convertNumericToFloat(statsDB.SpentClicks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CampaignStats{
+		Stats: domain.Stats{
+			ImpressionsCount:  statsDB.ImpressionsCount,
+			ClicksCount:       statsDB.ClicksCount,
+			CappedClicksCount: statsDB.CappedClicksCount,
+			SpentImpressions:  spentImpressions,
+			SpentClicks:       spentClicks,
+		},
+		ImpressionsOvershoot: statsDB.ImpressionsOvershoot,
+	}, nil
+}
+
+func (r *StatsRepository) GetCampaignDailyStats(ctx context.Context, campaignID uuid.UUID, currentDate int32) ([]domain.DailyStats, error) {
+	statsDB, err := r.queries.GetCampaignDailyStats(ctx, storage.GetCampaignDailyStatsParams{
+		CampaignID: campaignID,
+		CurDate:    currentDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dailyStats := make([]domain.DailyStats, len(statsDB))
+	for i, dayDB := range statsDB {
+		day, err := buildDailyStats(dayDB.Date, dayDB.ImpressionsCount, dayDB.ClicksCount, dayDB.CappedClicksCount, dayDB.SpentImpressions, dayDB.SpentClicks)
+		if err != nil {
+			return nil, err
+		}
+		dailyStats[i] = day
+	}
+	return dailyStats, nil
+}
+
+func (r *StatsRepository) GetAdvertiserDailyStats(ctx context.Context, advertiserID uuid.UUID, currentDate int32) ([]domain.DailyStats, error) {
+	statsDB, err := r.queries.GetAdvertiserDailyStats(ctx, storage.GetAdvertiserDailyStatsParams{
+		AdvertiserID: advertiserID,
+		CurDate:      currentDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dailyStats := make([]domain.DailyStats, len(statsDB))
+	for i, dayDB := range statsDB {
+		day, err := buildDailyStats(dayDB.Date, dayDB.ImpressionsCount, dayDB.ClicksCount, dayDB.CappedClicksCount, dayDB.SpentImpressions, dayDB.SpentClicks)
+		if err != nil {
+			return nil, err
+		}
+		dailyStats[i] = day
+	}
+	return dailyStats, nil
+}
+
+func buildDailyStats(date int32, impressionsCount, clicksCount, cappedClicks int64, spentImpressionsDB, spentClicksDB pgtype.Numeric) (domain.DailyStats, error) {
+	spentImpressions, err := convertNumericToFloat(spentImpressionsDB)
+	if err != nil {
+		return domain.DailyStats{}, err
+	}
+	spentClicks, err := convertNumericToFloat(spentClicksDB)
+	if err != nil {
+		return domain.DailyStats{}, err
+	}
+
+	return domain.DailyStats{
+		Date: date,
+		Stats: domain.Stats{
+			ImpressionsCount:  impressionsCount,
+			ClicksCount:       clicksCount,
+			CappedClicksCount: cappedClicks,
+			SpentImpressions:  spentImpressions,
+			SpentClicks:       spentClicks,
+		},
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/domain"
 	"gitlab.prodcontest.ru/2025-final-projects-back/misshanya/internal/infrastructure/db/sqlc/storage"
 )
@@ -23,22 +24,24 @@ func (r *UserRepository) CreateUpdateUsers(ctx context.Context, users []*domain.
 	for _, user := range users {
 		if _, err := r.queries.GetUserByID(ctx, user.ID); err == pgx.ErrNoRows {
 			_, err := r.queries.CreateUser(ctx, storage.CreateUserParams{
-				ID:       user.ID,
-				Login:    user.Login,
-				Age:      user.Age,
-				Location: user.Location,
-				Gender:   user.Gender,
+				ID:        user.ID,
+				Login:     user.Login,
+				Age:       user.Age,
+				Location:  user.Location,
+				Gender:    user.Gender,
+				Interests: user.Interests,
 			})
 			if err != nil {
 				return []*domain.User{}, err
 			}
 		} else if err == nil {
 			err := r.queries.UpdateUser(ctx, storage.UpdateUserParams{
-				ID:       user.ID,
-				Login:    user.Login,
-				Age:      user.Age,
-				Location: user.Location,
-				Gender:   user.Gender,
+				ID:        user.ID,
+				Login:     user.Login,
+				Age:       user.Age,
+				Location:  user.Location,
+				Gender:    user.Gender,
+				Interests: user.Interests,
 			})
 			if err != nil {
 				return []*domain.User{}, err
@@ -57,10 +60,47 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		return nil, err
 	}
 	return &domain.User{
-		ID:       user.ID,
-		Login:    user.Login,
-		Age:      user.Age,
-		Location: user.Location,
-		Gender:   user.Gender,
+		ID:        user.ID,
+		Login:     user.Login,
+		Age:       user.Age,
+		Location:  user.Location,
+		Gender:    user.Gender,
+		Interests: user.Interests,
 	}, nil
 }
+
+// GetByFilter returns clients matching the filter, it is used to estimate campaign audience
+func (r *UserRepository) GetByFilter(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	params := storage.GetUsersByFilterParams{
+		Genders:           filter.Genders,
+		Locations:         filter.Locations,
+		ExcludedLocations: filter.ExcludedLocations,
+		InterestsAny:      filter.InterestsAny,
+		InterestsAll:      filter.InterestsAll,
+		ClientIds:         filter.ClientIDs,
+	}
+	if filter.AgeFrom != nil {
+		params.AgeFrom = pgtype.Int4{Int32: *filter.AgeFrom, Valid: true}
+	}
+	if filter.AgeTo != nil {
+		params.AgeTo = pgtype.Int4{Int32: *filter.AgeTo, Valid: true}
+	}
+
+	usersDB, err := r.queries.GetUsersByFilter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.User, len(usersDB))
+	for i, user := range usersDB {
+		users[i] = domain.User{
+			ID:        user.ID,
+			Login:     user.Login,
+			Age:       user.Age,
+			Location:  user.Location,
+			Gender:    user.Gender,
+			Interests: user.Interests,
+		}
+	}
+	return users, nil
+}
@@ -80,41 +80,80 @@ func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
 	// Init user handler
 	userHandler := handlers.NewUserHandler(UserService)
 
+	// Init time and counter repositories
+	timeRepo := repository.NewTimeRepository(rdb)
+	counterRepo := repository.NewCounterRepository(rdb)
+
 	// Init advertiser repository and service
-	advertiserRepo := repository.NewAdvertiserRepository(queries)
-	advertiserService := app.NewAdvertiserService(*advertiserRepo, *userRepo)
+	advertiserRepo := repository.NewAdvertiserRepository(queries, conn)
+	advertiserService := app.NewAdvertiserService(*advertiserRepo, *userRepo, *timeRepo, *counterRepo)
 
 	// Init advertiser handler
 	advertiserHandler := handlers.NewAdvertiserHandler(advertiserService)
 
-	// Init time repository and service
-	timeRepo := repository.NewTimeRepository(rdb)
-	timeService := app.NewTimeService(*timeRepo)
+	// Init campaign repository and in-memory campaign index
+	campaignRepo := repository.NewCampaignRepository(queries, conn)
+	audienceRepo := repository.NewAudienceRepository(queries, conn)
+	campaignIndex := app.NewCampaignIndex(*campaignRepo, *audienceRepo)
+
+	// Init time service
+	timeService := app.NewTimeService(*timeRepo, *campaignRepo, *counterRepo, campaignIndex)
 
 	// Init time handler
 	timeHandler := handlers.NewTimeHandler(timeService)
 
-	// Init campaign repository and service
-	campaignRepo := repository.NewCampaignRepository(queries, conn)
+	// Init campaign service
 	campaignService := app.NewCampaignService(
 		*campaignRepo,
 		*advertiserRepo,
+		*audienceRepo,
 		*timeRepo,
 		openAIService,
 		*mlRepo,
 		*fileRepo,
-		cfg.MinIO.PublicHost)
+		cfg.MinIO.PublicHost,
+		cfg.AllowCampaignPurge,
+		campaignIndex)
 
 	// Init campaign handler
 	campaignHandler := handlers.NewCampaignHandler(campaignService)
 
 	// Init ads repository and service
-	adsRepo := repository.NewAdsRepository(queries)
-	adsService := app.NewAdsService(*adsRepo, *userRepo, *campaignRepo, *timeRepo)
+	adsRepo := repository.NewAdsRepository(queries, conn)
+	adsSelector := app.NewIndexAdSelector(campaignIndex, *counterRepo, *advertiserRepo, cfg.Ads)
+	adsRanker := app.NewRevenueRanker(cfg.Ads.RelevanceWeight, cfg.Ads.RevenueWeight, cfg.Ads.MaxMLScore)
+	adsService := app.NewAdsService(*adsRepo, *userRepo, *campaignRepo, *advertiserRepo, *timeRepo, *counterRepo, adsSelector, adsRanker, cfg.Ads)
+
+	// Load counters to redis if they are missing
+	if err := adsService.SyncCounters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to sync ads counters: %v", err)
+	}
 
 	// Init ads handler
 	adsHandler := handlers.NewAdsHandler(adsService)
 
+	// Init stats repository and service
+	statsRepo := repository.NewStatsRepository(queries)
+	statsService := app.NewStatsService(*statsRepo, *campaignRepo, *advertiserRepo, *timeRepo)
+
+	// Init stats handler
+	statsHandler := handlers.NewStatsHandler(statsService)
+
+	// Init invoice repository and service
+	invoiceRepo := repository.NewInvoiceRepository(queries)
+	invoiceService := app.NewInvoiceService(*invoiceRepo, *advertiserRepo, *timeRepo)
+
+	// Init invoice handler
+	invoiceHandler := handlers.NewInvoiceHandler(invoiceService)
+
+	// Init audience service and handler
+	audienceService := app.NewAudienceService(*audienceRepo, *advertiserRepo, campaignIndex)
+	audienceHandler := handlers.NewAudienceHandler(audienceService)
+
+	// Init estimate service and handler
+	estimateService := app.NewEstimateService(*userRepo, *advertiserRepo, *audienceRepo)
+	estimateHandler := handlers.NewEstimateHandler(estimateService)
+
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Use(jsonMiddleware)
@@ -127,14 +166,31 @@ func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
 
 	r.Post("/advertisers/bulk", advertiserHandler.CreateAdvertisers)
 	r.Get("/advertisers/{advertiserId}", advertiserHandler.GetByID)
+	r.Post("/advertisers/{advertiserId}/balance/topup", advertiserHandler.TopUpBalance)
+	r.Get("/advertisers/{advertiserId}/ledger", advertiserHandler.GetLedger)
+	r.Get("/advertisers/{advertiserId}/invoices", invoiceHandler.GetInvoice)
+
+	r.Post("/advertisers/{advertiserId}/audiences", audienceHandler.CreateAudience)
+	r.Get("/advertisers/{advertiserId}/audiences", audienceHandler.GetAudiences)
+	r.Get("/advertisers/{advertiserId}/audiences/{audienceId}", audienceHandler.GetAudience)
+	r.Put("/advertisers/{advertiserId}/audiences/{audienceId}", audienceHandler.UpdateAudience)
+	r.Delete("/advertisers/{advertiserId}/audiences/{audienceId}", audienceHandler.DeleteAudience)
 
 	r.Post("/ml-scores", advertiserHandler.CreateUpdateMLScore)
 
 	r.Post("/advertisers/{advertiserId}/campaigns", campaignHandler.CreateCampaign)
 	r.Get("/advertisers/{advertiserId}/campaigns", campaignHandler.GetCampaignsByAdvertiserID)
+	r.Post("/advertisers/{advertiserId}/campaigns/estimate", estimateHandler.EstimateAudience)
 	r.Get("/advertisers/{advertiserId}/campaigns/{campaignId}", campaignHandler.GetCampaignByID)
 	r.Put("/advertisers/{advertiserId}/campaigns/{campaignId}", campaignHandler.UpdateCampaign)
+	r.Patch("/advertisers/{advertiserId}/campaigns/{campaignId}", campaignHandler.PatchCampaign)
 	r.Delete("/advertisers/{advertiserId}/campaigns/{campaignId}", campaignHandler.DeleteCampaign)
+	r.Post("/advertisers/{advertiserId}/campaigns/{campaignId}/pause", campaignHandler.PauseCampaign)
+	r.Post("/advertisers/{advertiserId}/campaigns/{campaignId}/resume", campaignHandler.ResumeCampaign)
+	r.Post("/advertisers/{advertiserId}/campaigns/{campaignId}/archive", campaignHandler.ArchiveCampaign)
+	r.Post("/advertisers/{advertiserId}/campaigns/{campaignId}/clone", campaignHandler.CloneCampaign)
+	r.Get("/advertisers/{advertiserId}/campaigns/{campaignId}/versions", campaignHandler.GetCampaignVersions)
+	r.Post("/advertisers/{advertiserId}/campaigns/{campaignId}/versions/{version}/restore", campaignHandler.RestoreCampaignVersion)
 
 	r.Post("/advertisers/{advertiserId}/campaigns/{campaignId}/picture", campaignHandler.SetCampaignPicture)
 
@@ -145,6 +201,10 @@ func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
 	r.Get("/ads", adsHandler.GetAd)
 	r.Post("/ads/{adId}/click", adsHandler.Click)
 
+	r.Get("/stats/campaigns/{campaignId}", statsHandler.GetCampaignStats)
+	r.Get("/stats/campaigns/{campaignId}/daily", statsHandler.GetCampaignDailyStats)
+	r.Get("/stats/advertisers/{advertiserId}/campaigns/daily", statsHandler.GetAdvertiserDailyStats)
+
 	r.Post("/time/advance", timeHandler.SetCurrentDate)
 
 	return &Server{